@@ -22,3 +22,20 @@ func Benchmark_Decode_SmallStruct_UnmarshalPath_GoJson(b *testing.B) {
 		}
 	}
 }
+
+func Benchmark_Decode_SmallStruct_ExtractPathSet_GoJson(b *testing.B) {
+	set, err := gojson.CreatePathSet("$.st", "$.sid", "$.tt", "$.gr", "$.uuid")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		contents, err := set.Extract(SmallFixture)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(contents["$.st"]) != 1 {
+			b.Fatal("failed to extract path set")
+		}
+	}
+}
@@ -39,18 +39,14 @@ func unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
 		return err
 	}
 	ctx := decoder.TakeRuntimeContext()
-	ctx.Buf = src
 	ctx.Option.Flags = 0
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
-	cursor, err := dec.Decode(ctx, 0, 0, header.ptr)
-	if err != nil {
-		decoder.ReleaseRuntimeContext(ctx)
-		return err
-	}
+	ctx.Buf = src
+	err = decodeBuf(ctx, dec, header.ptr)
 	decoder.ReleaseRuntimeContext(ctx)
-	return validateEndBuf(src, cursor)
+	return err
 }
 
 func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
@@ -67,20 +63,17 @@ func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs
 		return err
 	}
 	rctx := decoder.TakeRuntimeContext()
-	rctx.Buf = src
 	rctx.Option.Flags = 0
 	rctx.Option.Flags |= decoder.ContextOption
 	rctx.Option.Context = ctx
 	for _, optFunc := range optFuncs {
 		optFunc(rctx.Option)
 	}
-	cursor, err := dec.Decode(rctx, 0, 0, header.ptr)
-	if err != nil {
-		decoder.ReleaseRuntimeContext(rctx)
-		return err
-	}
+	rctx.Buf = src
+	dec = decoder.GetFilteredDecoderIfNeeded(header.typ, dec, rctx.Option)
+	err = decodeBuf(rctx, dec, header.ptr)
 	decoder.ReleaseRuntimeContext(rctx)
-	return validateEndBuf(src, cursor)
+	return err
 }
 
 var (
@@ -91,6 +84,17 @@ func extractFromPath(path *Path, data []byte, optFuncs ...DecodeOptionFunc) ([][
 	if path.path.RootSelectorOnly {
 		return [][]byte{data}, nil
 	}
+	if path.path.RFC9535() {
+		matches, err := path.path.Matches(data)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([][]byte, 0, len(matches))
+		for _, match := range matches {
+			paths = append(paths, match.Value)
+		}
+		return paths, nil
+	}
 	src := make([]byte, len(data)+1) // append nul byte to the end
 	copy(src, data)
 
@@ -129,18 +133,38 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	}
 
 	ctx := decoder.TakeRuntimeContext()
-	ctx.Buf = src
 	ctx.Option.Flags = 0
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
-	cursor, err := dec.Decode(ctx, 0, 0, noescape(header.ptr))
-	if err != nil {
-		decoder.ReleaseRuntimeContext(ctx)
+	ctx.Buf = src
+	err = decodeBuf(ctx, dec, noescape(header.ptr))
+	decoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+// decodeBuf decodes the top-level value in ctx.Buf, and validates that nothing follows it.
+// The comments around the value are skipped too if DecodeRelaxed is set.
+func decodeBuf(ctx *decoder.RuntimeContext, dec decoder.Decoder, p unsafe.Pointer) error {
+	relaxed := ctx.Option.Flags&decoder.RelaxedOption != 0
+	var (
+		cursor int64
+		err    error
+	)
+	if relaxed {
+		if cursor, err = decoder.SkipRelaxedWhiteSpace(ctx.Buf, cursor); err != nil {
+			return err
+		}
+	}
+	if cursor, err = dec.Decode(ctx, cursor, 0, p); err != nil {
 		return err
 	}
-	decoder.ReleaseRuntimeContext(ctx)
-	return validateEndBuf(src, cursor)
+	if relaxed {
+		if cursor, err = decoder.SkipRelaxedWhiteSpace(ctx.Buf, cursor); err != nil {
+			return err
+		}
+	}
+	return validateEndBuf(ctx.Buf, cursor)
 }
 
 func validateEndBuf(src []byte, cursor int64) error {
@@ -177,11 +201,22 @@ func validateType(typ *runtime.Type, p uintptr) error {
 //
 // The decoder introduces its own buffering and may
 // read data from r beyond the JSON values requested.
-func NewDecoder(r io.Reader) *Decoder {
+// The options are applied to all the decoding by the decoder like SetOptions.
+func NewDecoder(r io.Reader, optFuncs ...DecodeOptionFunc) *Decoder {
 	s := decoder.NewStream(r)
-	return &Decoder{
+	d := &Decoder{
 		s: s,
 	}
+	d.SetOptions(optFuncs...)
+	return d
+}
+
+// SetOptions sets the options applied to all the subsequent decoding by the decoder.
+// The options passed to DecodeWithOption are applied after them only for the call.
+func (d *Decoder) SetOptions(optFuncs ...DecodeOptionFunc) {
+	for _, optFunc := range optFuncs {
+		optFunc(d.s.Option)
+	}
 }
 
 // Buffered returns a reader of the data remaining in the Decoder's
@@ -201,9 +236,11 @@ func (d *Decoder) Decode(v interface{}) error {
 
 // DecodeContext reads the next JSON-encoded value from its
 // input and stores it in the value pointed to by v with context.Context.
+//
+// Reading and decoding are aborted when ctx is done, and *CanceledError wrapping ctx.Err() is returned.
+// ctx is kept by the Decoder, so the following calls reading from it are also aborted.
 func (d *Decoder) DecodeContext(ctx context.Context, v interface{}) error {
-	d.s.Option.Flags |= decoder.ContextOption
-	d.s.Option.Context = ctx
+	d.s.SetContext(ctx)
 	return d.DecodeWithOption(v)
 }
 
@@ -223,33 +260,103 @@ func (d *Decoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc)
 	if err != nil {
 		return err
 	}
-	if err := d.s.PrepareForDecode(); err != nil {
-		return err
-	}
 	s := d.s
-	for _, optFunc := range optFuncs {
-		optFunc(s.Option)
+	if len(optFuncs) > 0 {
+		defaultOption := *s.Option
+		defer func() {
+			*s.Option = defaultOption
+		}()
+		for _, optFunc := range optFuncs {
+			optFunc(s.Option)
+		}
 	}
-	if err := dec.DecodeStream(s, 0, header.ptr); err != nil {
-		return err
+	if err := s.PrepareForDecode(); err != nil {
+		return s.ContextError(err)
+	}
+	dec = decoder.GetFilteredDecoderIfNeeded(typ, dec, s.Option)
+	if err := dec.DecodeStream(s, s.Depth(), header.ptr); err != nil {
+		return s.ContextError(err)
 	}
 	s.Reset()
 	return nil
 }
 
+// DecodeArrayEach reads the next JSON array from its input and calls fn for each element.
+// fn is called with the Decoder positioned at the element, so the element can be decoded by dec.Decode
+// or the other methods reading the next value. The element is skipped if fn doesn't read it.
+// Unlike decoding the whole array, the buffer doesn't grow with the number of elements.
+func (d *Decoder) DecodeArrayEach(fn func(i int, dec *Decoder) error) error {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return d.s.ContextError(err)
+	}
+	if err := d.s.DecodeArrayEach(func(i int) error {
+		return fn(i, d)
+	}); err != nil {
+		return d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return nil
+}
+
+// DecodeObjectEach reads the next JSON object from its input and calls fn for each member.
+// fn is called with the Decoder positioned at the value of the member in the same way as DecodeArrayEach.
+func (d *Decoder) DecodeObjectEach(fn func(key string, dec *Decoder) error) error {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return d.s.ContextError(err)
+	}
+	if err := d.s.DecodeObjectEach(func(key string) error {
+		return fn(key, d)
+	}); err != nil {
+		return d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return nil
+}
+
+// SkipValue skips the next JSON-encoded value from its input without decoding it.
+// The value is skipped without allocating, and the buffer doesn't grow with the size of the value.
+func (d *Decoder) SkipValue() error {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return d.s.ContextError(err)
+	}
+	if err := d.s.SkipValue(d.s.Depth()); err != nil {
+		return d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return nil
+}
+
+// ReadRawValue reads the next JSON-encoded value from its input and returns its bytes without decoding them.
+// Unlike decoding into RawMessage, the value goes through neither the compiled decoder nor UnmarshalJSON.
+func (d *Decoder) ReadRawValue() (RawMessage, error) {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	raw, err := d.s.RawValue(d.s.Depth())
+	if err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return raw, nil
+}
+
 func (d *Decoder) More() bool {
 	return d.s.More()
 }
 
 func (d *Decoder) Token() (Token, error) {
-	return d.s.Token()
+	token, err := d.s.Token()
+	if err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	return token, nil
 }
 
 // DisallowUnknownFields causes the Decoder to return an error when the destination
 // is a struct and the input contains object keys which do not match any
 // non-ignored, exported fields in the destination.
 func (d *Decoder) DisallowUnknownFields() {
-	d.s.DisallowUnknownFields = true
+	d.s.Option.Flags |= decoder.DisallowUnknownFieldsOption
 }
 
 func (d *Decoder) InputOffset() int64 {
@@ -259,5 +366,59 @@ func (d *Decoder) InputOffset() int64 {
 // UseNumber causes the Decoder to unmarshal a number into an interface{} as a
 // Number instead of as a float64.
 func (d *Decoder) UseNumber() {
-	d.s.UseNumber = true
+	d.s.Option.Flags |= decoder.UseNumberOption
+}
+
+// ExtractPath reads the next JSON-encoded value from its input
+// and returns the parts corresponding to JSON Path.
+//
+// Unlike Path.Extract, the whole value is never kept in memory.
+// The parts that don't correspond to the path are skipped while reading.
+func (d *Decoder) ExtractPath(path *Path) ([][]byte, error) {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	contents := [][]byte{}
+	if err := path.path.DecodeStream(d.s, func(s *decoder.Stream, depth int64) error {
+		content, err := s.RawValue(depth)
+		if err != nil {
+			return err
+		}
+		contents = append(contents, content)
+		return nil
+	}); err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return contents, nil
+}
+
+// DecodePath reads the next JSON-encoded value from its input,
+// decodes the parts corresponding to JSON Path and stores them in the value pointed to by v.
+//
+// Unlike Path.Unmarshal, the whole value is never kept in memory.
+// Only the parts that correspond to the path are decoded, the others are skipped while reading.
+func (d *Decoder) DecodePath(path *Path, v interface{}) error {
+	if err := d.s.PrepareForDecode(); err != nil {
+		return d.s.ContextError(err)
+	}
+	results := []interface{}{}
+	if err := path.path.DecodeStream(d.s, func(s *decoder.Stream, depth int64) error {
+		var result interface{}
+		ptr := interface{}(&result)
+		header := (*emptyInterface)(unsafe.Pointer(&ptr))
+		dec, err := decoder.CompileToGetDecoder(header.typ)
+		if err != nil {
+			return err
+		}
+		if err := dec.DecodeStream(s, depth, header.ptr); err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	}); err != nil {
+		return d.s.ContextError(err)
+	}
+	d.s.Reset()
+	return decoder.AssignValue(reflect.ValueOf(results), reflect.ValueOf(v))
 }
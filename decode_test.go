@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"io"
 	"math"
 	"math/big"
 	"net"
@@ -15,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 	"unsafe"
 
@@ -3698,6 +3700,95 @@ func TestDecodeContextOption(t *testing.T) {
 	})
 }
 
+// cancelReader cancels the context after reading n times.
+type cancelReader struct {
+	r      io.Reader
+	n      int
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Read(b []byte) (int, error) {
+	r.n--
+	if r.n == 0 {
+		r.cancel()
+	}
+	return r.r.Read(b)
+}
+
+type cancelElem struct {
+	cancel context.CancelFunc
+	count  *int
+}
+
+func (e *cancelElem) UnmarshalJSON(ctx context.Context, b []byte) error {
+	*e.count++
+	if *e.count == 10 {
+		e.cancel()
+	}
+	return nil
+}
+
+func TestDecodeContextCancel(t *testing.T) {
+	assertCanceled := func(t *testing.T, err error) *json.CanceledError {
+		t.Helper()
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled but got %v", err)
+		}
+		var canceled *json.CanceledError
+		if !errors.As(err, &canceled) {
+			t.Fatalf("expected *json.CanceledError but got %T", err)
+		}
+		return canceled
+	}
+	src := "[" + strings.Repeat(`{"a": "b"},`, 10000) + `{"a": "b"}]`
+	t.Run("read", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dec := json.NewDecoder(&cancelReader{r: iotest.HalfReader(strings.NewReader(src)), n: 5, cancel: cancel})
+		var v []map[string]string
+		canceled := assertCanceled(t, dec.DecodeContext(ctx, &v))
+		if canceled.Offset == 0 || canceled.Offset >= int64(len(src)) {
+			t.Fatalf("unexpected offset %d", canceled.Offset)
+		}
+		if _, err := dec.ReadRawValue(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled but got %v", err)
+		}
+	})
+	t.Run("buffered", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		count := 0
+		elems := make([]cancelElem, 200)
+		for i := range elems {
+			elems[i] = cancelElem{cancel: cancel, count: &count}
+		}
+		src := "[" + strings.Repeat("1,", len(elems)-1) + "1]"
+		dec := json.NewDecoder(strings.NewReader(src))
+		assertCanceled(t, dec.DecodeContext(ctx, &elems))
+		if count >= len(elems) {
+			t.Fatalf("decoding wasn't aborted: %d elements are decoded", count)
+		}
+	})
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		dec := json.NewDecoder(strings.NewReader(src))
+		var v []map[string]string
+		if err := dec.DecodeContext(ctx, &v); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded but got %v", err)
+		}
+	})
+	t.Run("not canceled", func(t *testing.T) {
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src)))
+		var v []map[string]string
+		if err := dec.DecodeContext(context.Background(), &v); err != nil {
+			t.Fatal(err)
+		}
+		assertEq(t, "length", 10001, len(v))
+	})
+}
+
 func TestIssue251(t *testing.T) {
 	array := [3]int{1, 2, 3}
 	err := stdjson.Unmarshal([]byte("[ ]"), &array)
@@ -4057,3 +4148,342 @@ func TestIssue429(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeOptionParity(t *testing.T) {
+	type T struct {
+		A int `json:"a"`
+	}
+	t.Run("UseNumber", func(t *testing.T) {
+		src := `{"v": 1.5}`
+		var v map[string]interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeUseNumber()))
+		assertEq(t, "unmarshal", json.Number("1.5"), v["v"])
+		assertErr(t, json.UnmarshalContext(context.Background(), []byte(src), &v, json.DecodeUseNumber()))
+		assertEq(t, "unmarshal context", json.Number("1.5"), v["v"])
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).DecodeWithOption(&v, json.DecodeUseNumber()))
+		assertEq(t, "decode", json.Number("1.5"), v["v"])
+		assertErr(t, json.NewDecoder(strings.NewReader(src), json.DecodeUseNumber()).Decode(&v))
+		assertEq(t, "decoder option", json.Number("1.5"), v["v"])
+	})
+	t.Run("DisallowUnknownFields", func(t *testing.T) {
+		src := `{"a": 1, "b!": 2}`
+		var v T
+		err := json.UnmarshalWithOption([]byte(src), &v, json.DecodeDisallowUnknownFields())
+		if err == nil || err.Error() != `json: unknown field "b!"` {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := json.NewDecoder(strings.NewReader(src)).DecodeWithOption(&v, json.DecodeDisallowUnknownFields()); err == nil {
+			t.Fatal("expected error")
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"a": 1}`), &v, json.DecodeDisallowUnknownFields()))
+		assertEq(t, "known field", 1, v.A)
+	})
+	t.Run("SetOptions", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"a": 1} {"a": 1, "b": 2} {"a": 1, "b": 2}`))
+		var v T
+		// the options passed to DecodeWithOption are applied only for the call.
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeDisallowUnknownFields()))
+		assertErr(t, dec.Decode(&v))
+		dec.SetOptions(json.DecodeDisallowUnknownFields())
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestDecodeRelaxed(t *testing.T) {
+	type T struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Port  int      `json:"port"`
+		Quote string   `json:"quote"`
+	}
+	src := `// config
+{
+	name: 'server', /* the name */
+	tags: ['a', "b",],
+	$port: 1,
+	port: 8080, // trailing comma
+	quote: 'it\'s "ok"',
+}
+`
+	expected := T{Name: "server", Tags: []string{"a", "b"}, Port: 8080, Quote: `it's "ok"`}
+	check := func(t *testing.T, v T) {
+		t.Helper()
+		assertEq(t, "value", fmt.Sprintf("%q", expected), fmt.Sprintf("%q", v))
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v T
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeRelaxed()))
+		check(t, v)
+		var m map[string]interface{}
+		assertErr(t, json.UnmarshalContext(context.Background(), []byte(src), &m, json.DecodeRelaxed()))
+		assertEq(t, "map", "server", m["name"])
+		assertEq(t, "map key", float64(1), m["$port"])
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v T
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src+src)), json.DecodeRelaxed())
+		assertErr(t, dec.Decode(&v))
+		check(t, v)
+		v = T{}
+		assertErr(t, dec.Decode(&v))
+		check(t, v)
+		if err := dec.Decode(&v); err != io.EOF {
+			t.Fatalf("expected io.EOF but got %v", err)
+		}
+	})
+	t.Run("DecodeWithOption", func(t *testing.T) {
+		var v T
+		dec := json.NewDecoder(strings.NewReader(src))
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeRelaxed()))
+		check(t, v)
+	})
+	t.Run("Strict", func(t *testing.T) {
+		var v T
+		if err := json.Unmarshal([]byte(src), &v); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := json.NewDecoder(strings.NewReader(src)).Decode(&v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var v interface{}
+		for _, src := range []string{`{a: 1 / 2}`, `[1,,]`, `{"a": 1 /* unterminated`} {
+			if err := json.UnmarshalWithOption([]byte(src), &v, json.DecodeRelaxed()); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+	})
+	t.Run("DecodeWithOptionOneCall", func(t *testing.T) {
+		var v T
+		dec := json.NewDecoder(strings.NewReader(src + src))
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeRelaxed()))
+		check(t, v)
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Offset", func(t *testing.T) {
+		src := `{name: 'it\'s', tags: ]}`
+		expected := int64(strings.Index(src, "]"))
+		var v T
+		err := json.UnmarshalWithOption([]byte(src), &v, json.DecodeRelaxed())
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		assertEq(t, "offset", expected, syntaxErr.Offset)
+		err = json.NewDecoder(strings.NewReader(src), json.DecodeRelaxed()).Decode(&v)
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		assertEq(t, "stream offset", expected, syntaxErr.Offset)
+	})
+	t.Run("UnterminatedComment", func(t *testing.T) {
+		var v interface{}
+		for _, src := range []string{`{"a": 1 /* unterminated`, `[1, /* unterminated`, `/* unterminated`} {
+			err := json.UnmarshalWithOption([]byte(src), &v, json.DecodeRelaxed())
+			if err == nil || !strings.Contains(err.Error(), "unterminated comment") {
+				t.Fatalf("unexpected error for %s: %v", src, err)
+			}
+			err = json.NewDecoder(strings.NewReader(src), json.DecodeRelaxed()).Decode(&v)
+			if err == nil || !strings.Contains(err.Error(), "unterminated comment") {
+				t.Fatalf("unexpected stream error for %s: %v", src, err)
+			}
+		}
+	})
+	t.Run("Values", func(t *testing.T) {
+		var n int
+		assertErr(t, json.UnmarshalWithOption([]byte("// c\n1 /* x */"), &n, json.DecodeRelaxed()))
+		assertEq(t, "scalar", 1, n)
+		n = 0
+		assertErr(t, json.NewDecoder(strings.NewReader("// c\n1 /* x */"), json.DecodeRelaxed()).Decode(&n))
+		assertEq(t, "stream scalar", 1, n)
+
+		var m map[int]string
+		assertErr(t, json.UnmarshalWithOption([]byte(`{1: 'a', 2: "b",}`), &m, json.DecodeRelaxed()))
+		assertEq(t, "int key", "map[1:a 2:b]", fmt.Sprint(m))
+
+		var raw struct {
+			Raw json.RawMessage `json:"raw"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{raw: {a: ['b', /* c */],},}`), &raw, json.DecodeRelaxed()))
+		assertEq(t, "unmarshaler", `{"a": ["b"  ]}`, string(raw.Raw))
+
+		var o json.OrderedObject
+		assertErr(t, json.UnmarshalWithOption([]byte(`{b: 'x', a: 1,}`), &o, json.DecodeRelaxed()))
+		assertEq(t, "ordered keys", `["b" "a"]`, fmt.Sprintf("%q", o.Keys()))
+
+		var arr [2]string
+		assertErr(t, json.NewDecoder(strings.NewReader(`['a', 'b',]`), json.DecodeRelaxed()).Decode(&arr))
+		assertEq(t, "array", `["a" "b"]`, fmt.Sprintf("%q", arr))
+	})
+	t.Run("StripComments", func(t *testing.T) {
+		src := "{\"a\": \"// not a comment\", // comment\n\"b\": /* x\ny */ 1}"
+		got := json.StripComments([]byte(src))
+		assertEq(t, "stripped", "{\"a\": \"// not a comment\",           \n\"b\":     \n     1}", string(got))
+		assertEq(t, "length", len(src), len(got))
+		var m map[string]interface{}
+		assertErr(t, json.Unmarshal(got, &m))
+		assertEq(t, "b", float64(1), m["b"])
+	})
+}
+
+func TestDecodeObjectAsOrdered(t *testing.T) {
+	src := `{"z": 1, "a": {"y": [true, {"c": null, "b": "x"}], "x": 2}, "m": "<>"}`
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeObjectAsOrdered()))
+		obj, ok := v.(json.OrderedObject)
+		if !ok {
+			t.Fatalf("unexpected type %T", v)
+		}
+		assertEq(t, "keys", `["z" "a" "m"]`, fmt.Sprintf("%q", obj.Keys()))
+		a, ok := obj.Get("a")
+		if !ok {
+			t.Fatal("expected key a")
+		}
+		assertEq(t, "nested keys", `["y" "x"]`, fmt.Sprintf("%q", a.(json.OrderedObject).Keys()))
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "round trip", `{"z":1,"a":{"y":[true,{"c":null,"b":"x"}],"x":2},"m":"\u003c\u003e"}`, string(got))
+		got, err = json.MarshalWithOption(v, json.DisableHTMLEscape())
+		assertErr(t, err)
+		assertEq(t, "no escape", `{"z":1,"a":{"y":[true,{"c":null,"b":"x"}],"x":2},"m":"<>"}`, string(got))
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v interface{}
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src)), json.DecodeObjectAsOrdered(), json.DecodeUseNumber())
+		assertErr(t, dec.Decode(&v))
+		got, err := json.MarshalWithOption(v, json.DisableHTMLEscape())
+		assertErr(t, err)
+		assertEq(t, "round trip", `{"z":1,"a":{"y":[true,{"c":null,"b":"x"}],"x":2},"m":"<>"}`, string(got))
+		z, _ := v.(json.OrderedObject).Get("z")
+		assertEq(t, "use number", json.Number("1"), z)
+	})
+	t.Run("Default", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		if _, ok := v.(map[string]interface{}); !ok {
+			t.Fatalf("unexpected type %T", v)
+		}
+	})
+	t.Run("Direct", func(t *testing.T) {
+		var v struct {
+			O json.OrderedObject `json:"o"`
+			N json.OrderedObject `json:"n"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"o": {"b": 1, "a": {"d": 2, "c": 3}, "b": 4}, "n": null}`), &v))
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "duplicated key", `{"o":{"b":4,"a":{"d":2,"c":3}},"n":null}`, string(got))
+		got, err = json.MarshalIndent(v.O, "", " ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"b\": 4,\n \"a\": {\n  \"d\": 2,\n  \"c\": 3\n }\n}", string(got))
+		var o json.OrderedObject
+		if err := json.Unmarshal([]byte(`[1]`), &o); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Options", func(t *testing.T) {
+		var o json.OrderedObject
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"n": 12345678901234567890, "a": {"m": 1}}`), &o, json.DecodeUseNumber()))
+		n, _ := o.Get("n")
+		assertEq(t, "use number", json.Number("12345678901234567890"), n)
+		a, _ := o.Get("a")
+		m, _ := a.(json.OrderedObject).Get("m")
+		assertEq(t, "nested use number", json.Number("1"), m)
+
+		var v struct {
+			O json.OrderedObject `json:"o"`
+		}
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(`{"o": {"n": 1}}`)), json.DecodeUseNumber())
+		assertErr(t, dec.Decode(&v))
+		n, _ = v.O.Get("n")
+		assertEq(t, "stream use number", json.Number("1"), n)
+	})
+	t.Run("DuplicatedKeys", func(t *testing.T) {
+		var keys []string
+		for i := 0; i < 20; i++ {
+			keys = append(keys, fmt.Sprintf(`"k%d": %d`, i%12, i))
+		}
+		var o json.OrderedObject
+		assertErr(t, json.Unmarshal([]byte("{"+strings.Join(keys, ", ")+"}"), &o))
+		got, err := json.Marshal(o)
+		assertErr(t, err)
+		assertEq(t, "large", `{"k0":12,"k1":13,"k2":14,"k3":15,"k4":16,"k5":17,"k6":18,"k7":19,"k8":8,"k9":9,"k10":10,"k11":11}`, string(got))
+	})
+	t.Run("Edit", func(t *testing.T) {
+		var o json.OrderedObject
+		o.Set("b", 1)
+		o.Set("a", 2)
+		o.Set("b", 3)
+		assertEq(t, "deleted", true, o.Delete("a"))
+		assertEq(t, "not found", false, o.Delete("a"))
+		o.Set("c", []int{1})
+		got, err := json.Marshal(o)
+		assertErr(t, err)
+		assertEq(t, "edited", `{"b":3,"c":[1]}`, string(got))
+		o.Set("h", map[string]string{"x": "<>"})
+		got, err = json.MarshalWithOption(o, json.DisableHTMLEscape())
+		assertErr(t, err)
+		assertEq(t, "no escape", `{"b":3,"c":[1],"h":{"x":"<>"}}`, string(got))
+		if _, ok := o.Get("a"); ok {
+			t.Fatal("unexpected key a")
+		}
+	})
+}
+
+func TestDecodeIntegersAsInt64(t *testing.T) {
+	src := `{"i": 9007199254740993, "n": -5, "u": 18446744073709551615, "big": 18446744073709551616, "neg": -9223372036854775809, "f": 1.5, "e": 1e2, "a": [1, 2.0, {"x": 3}]}`
+	check := func(t *testing.T, v interface{}, floats interface{}) {
+		t.Helper()
+		m := v.(map[string]interface{})
+		assertEq(t, "int", int64(9007199254740993), m["i"])
+		assertEq(t, "negative", int64(-5), m["n"])
+		assertEq(t, "uint", uint64(18446744073709551615), m["u"])
+		assertEq(t, "number", json.Number("18446744073709551616"), m["big"])
+		assertEq(t, "negative number", json.Number("-9223372036854775809"), m["neg"])
+		assertEq(t, "float", fmt.Sprint(floats), fmt.Sprint([]interface{}{m["f"], m["e"]}))
+		a := m["a"].([]interface{})
+		assertEq(t, "slice", int64(1), a[0])
+		assertEq(t, "slice float", fmt.Sprintf("%T", floats.([]interface{})[0]), fmt.Sprintf("%T", a[1]))
+		assertEq(t, "nested map", int64(3), a[2].(map[string]interface{})["x"])
+	}
+	floats := []interface{}{1.5, float64(100)}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeIntegersAsInt64()))
+		check(t, v, floats)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v interface{}
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src)), json.DecodeIntegersAsInt64())
+		assertErr(t, dec.Decode(&v))
+		check(t, v, floats)
+	})
+	t.Run("UseNumber", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeIntegersAsInt64(), json.DecodeUseNumber()))
+		check(t, v, []interface{}{json.Number("1.5"), json.Number("1e2")})
+	})
+	t.Run("Typed", func(t *testing.T) {
+		var v struct {
+			F float64     `json:"f"`
+			I interface{} `json:"i"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"f": 2, "i": 2}`), &v, json.DecodeIntegersAsInt64()))
+		assertEq(t, "float field", float64(2), v.F)
+		assertEq(t, "interface field", int64(2), v.I)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var v interface{}
+		for _, src := range []string{`[1-2]`, `[1e]`, `-`} {
+			if err := json.UnmarshalWithOption([]byte(src), &v, json.DecodeIntegersAsInt64()); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package json
+
+import (
+	"github.com/goccy/go-json/internal/decoder"
+)
+
+// Kind is the kind of the next token returned by Decoder.PeekKind.
+type Kind = decoder.Kind
+
+const (
+	// InvalidKind is returned at the end of the input or if the next character can't begin a token.
+	InvalidKind     = decoder.InvalidKind
+	NullKind        = decoder.NullKind
+	BoolKind        = decoder.BoolKind
+	NumberKind      = decoder.NumberKind
+	StringKind      = decoder.StringKind
+	ObjectStartKind = decoder.ObjectStartKind
+	ObjectEndKind   = decoder.ObjectEndKind
+	ArrayStartKind  = decoder.ArrayStartKind
+	ArrayEndKind    = decoder.ArrayEndKind
+)
+
+// PeekKind returns the kind of the next token without reading it.
+//
+// PeekKind and the Read methods are the low-level alternative to Token.
+// They work on the buffer of the Decoder directly, so no token is boxed into interface{}.
+// The separators ',' and ':' are skipped in the same way as Token.
+func (d *Decoder) PeekKind() Kind {
+	return d.s.PeekKind()
+}
+
+// ReadString reads the next string token and returns its unescaped bytes.
+// The bytes refer to the buffer of the Decoder and are valid until the next call to read from it.
+// No allocation is made for the string without escape sequences.
+func (d *Decoder) ReadString() ([]byte, error) {
+	b, err := d.s.ReadString()
+	if err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	return b, nil
+}
+
+// ReadNumber reads the next number token and returns its literal bytes.
+// The bytes refer to the buffer of the Decoder and are valid until the next call to read from it.
+func (d *Decoder) ReadNumber() ([]byte, error) {
+	b, err := d.s.ReadNumber()
+	if err != nil {
+		return nil, d.s.ContextError(err)
+	}
+	return b, nil
+}
+
+// ReadBool reads the next true or false token.
+func (d *Decoder) ReadBool() (bool, error) {
+	v, err := d.s.ReadBool()
+	if err != nil {
+		return false, d.s.ContextError(err)
+	}
+	return v, nil
+}
+
+// ReadNull reads the next null token.
+func (d *Decoder) ReadNull() error {
+	return d.s.ContextError(d.s.ReadNull())
+}
+
+// ReadDelim reads the next delimiter token, one of '{', '}', '[' or ']'.
+// The delimiters beginning objects and arrays increase Depth, and the ones ending them decrease it.
+func (d *Decoder) ReadDelim() (Delim, error) {
+	c, err := d.s.ReadDelim()
+	if err != nil {
+		return 0, d.s.ContextError(err)
+	}
+	return Delim(c), nil
+}
+
+// Depth returns the number of the objects and arrays the Decoder is reading.
+// It's updated by ReadDelim, DecodeArrayEach and DecodeObjectEach.
+func (d *Decoder) Depth() int {
+	return int(d.s.Depth())
+}
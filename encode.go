@@ -20,11 +20,17 @@ type Encoder struct {
 	enabledHTMLEscape bool
 	prefix            string
 	indentStr         string
+	optFuncs          []EncodeOptionFunc
+	scopes            []encodeTokenScope
+	written           int64
 }
 
 // NewEncoder returns a new encoder that writes to w.
-func NewEncoder(w io.Writer) *Encoder {
-	return &Encoder{w: w, enabledHTMLEscape: true}
+// The options are applied to all the encoding by the encoder like SetOptions.
+func NewEncoder(w io.Writer, optFuncs ...EncodeOptionFunc) *Encoder {
+	e := &Encoder{w: w, enabledHTMLEscape: true}
+	e.SetOptions(optFuncs...)
+	return e
 }
 
 // Encode writes the JSON encoding of v to the stream, followed by a newline character.
@@ -59,38 +65,72 @@ func (e *Encoder) EncodeContext(ctx context.Context, v interface{}, optFuncs ...
 }
 
 func (e *Encoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	if len(e.scopes) > 0 {
+		// the value inside of the object or array opened by the token level writing is written by WriteValue.
+		return e.tokenError("json: Encode called inside of object or array")
+	}
+	e.setOption(ctx, optFuncs...)
+	ctx.Writer = (*encoderWriter)(e)
+	buf, err := encodeWithIndentOption(ctx, v)
+	if err != nil {
+		return err
+	}
+	buf = append(trimEncoded(ctx, buf), '\n')
+	if _, err := ctx.Writer.Write(buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// encoderWriter writes the encoded bytes to the destination of Encoder, and counts them.
+type encoderWriter Encoder
+
+func (w *encoderWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+func (e *Encoder) setOption(ctx *encoder.RuntimeContext, optFuncs ...EncodeOptionFunc) {
 	if e.enabledHTMLEscape {
 		ctx.Option.Flag |= encoder.HTMLEscapeOption
 	}
 	ctx.Option.Flag |= encoder.NormalizeUTF8Option
 	ctx.Option.DebugOut = os.Stdout
-	for _, optFunc := range optFuncs {
-		optFunc(ctx.Option)
-	}
-	var (
-		buf []byte
-		err error
-	)
 	if e.enabledIndent {
-		buf, err = encodeIndent(ctx, v, e.prefix, e.indentStr)
-	} else {
-		buf, err = encode(ctx, v)
+		ctx.Option.Flag |= encoder.IndentOption
+		ctx.Option.Prefix = e.prefix
+		ctx.Option.Indent = e.indentStr
 	}
-	if err != nil {
-		return err
+	for _, optFunc := range e.optFuncs {
+		optFunc(ctx.Option)
 	}
-	if e.enabledIndent {
-		buf = buf[:len(buf)-2]
-	} else {
-		buf = buf[:len(buf)-1]
+	for _, optFunc := range optFuncs {
+		optFunc(ctx.Option)
 	}
-	buf = append(buf, '\n')
-	if _, err := e.w.Write(buf); err != nil {
-		return err
+}
+
+// SetOptions sets the options applied to all the subsequent encoding by the encoder.
+// The options passed to EncodeWithOption and EncodeContext are applied after them.
+func (e *Encoder) SetOptions(optFuncs ...EncodeOptionFunc) {
+	e.optFuncs = optFuncs
+
+	// the indentation is also used by the token level writing, so it's kept in the encoder like SetIndent.
+	var opt EncodeOption
+	for _, optFunc := range optFuncs {
+		optFunc(&opt)
+	}
+	if (opt.Flag & encoder.IndentOption) != 0 {
+		e.SetIndent(opt.Prefix, opt.Indent)
 	}
-	return nil
 }
 
+// Stream is the iterator encoded as JSON array of the elements passed to yield.
+// The encoding stops with the error returned by it.
+// Like the channels and the iterators of `func(yield func(T) bool)`,
+// Encoder writes each element to the writer as soon as it's encoded.
+type Stream[T any] func(yield func(T) bool) error
+
 // SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings.
 // The default behavior is to escape &, <, and > to \u0026, \u003c, and \u003e to avoid certain safety problems that can arise when embedding JSON in HTML.
 //
@@ -120,7 +160,7 @@ func marshalContext(ctx context.Context, v interface{}, optFuncs ...EncodeOption
 		optFunc(rctx.Option)
 	}
 
-	buf, err := encode(rctx, v) //nolint: contextcheck
+	buf, err := encodeWithIndentOption(rctx, v) //nolint: contextcheck
 	if err != nil {
 		encoder.ReleaseRuntimeContext(rctx)
 		return nil, err
@@ -130,7 +170,7 @@ func marshalContext(ctx context.Context, v interface{}, optFuncs ...EncodeOption
 	// if use `make([]byte, len(buf)-1)` and `copy(copied, buf)`,
 	// dst buffer size and src buffer size are differrent.
 	// in this case, compiler uses `runtime.makeslicecopy`, but it is slow.
-	buf = buf[:len(buf)-1]
+	buf = trimEncoded(rctx, buf)
 	copied := make([]byte, len(buf))
 	copy(copied, buf)
 
@@ -147,7 +187,7 @@ func marshal(v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 		optFunc(ctx.Option)
 	}
 
-	buf, err := encode(ctx, v)
+	buf, err := encodeWithIndentOption(ctx, v)
 	if err != nil {
 		encoder.ReleaseRuntimeContext(ctx)
 		return nil, err
@@ -157,7 +197,7 @@ func marshal(v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 	// if use `make([]byte, len(buf)-1)` and `copy(copied, buf)`,
 	// dst buffer size and src buffer size are differrent.
 	// in this case, compiler uses `runtime.makeslicecopy`, but it is slow.
-	buf = buf[:len(buf)-1]
+	buf = trimEncoded(ctx, buf)
 	copied := make([]byte, len(buf))
 	copy(copied, buf)
 
@@ -212,6 +252,22 @@ func marshalIndent(v interface{}, prefix, indent string, optFuncs ...EncodeOptio
 	return copied, nil
 }
 
+// encodeWithIndentOption encodes v with the indentation if it's enabled by IndentWith.
+func encodeWithIndentOption(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
+	if (ctx.Option.Flag & encoder.IndentOption) != 0 {
+		return encodeIndent(ctx, v, ctx.Option.Prefix, ctx.Option.Indent)
+	}
+	return encode(ctx, v)
+}
+
+// trimEncoded removes the separator appended after the value encoded by encodeWithIndentOption.
+func trimEncoded(ctx *encoder.RuntimeContext, buf []byte) []byte {
+	if (ctx.Option.Flag & encoder.IndentOption) != 0 {
+		return buf[:len(buf)-2]
+	}
+	return buf[:len(buf)-1]
+}
+
 func encode(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
 	b := ctx.Buf[:0]
 	if v == nil {
@@ -268,6 +324,11 @@ func encodeNoEscape(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error)
 }
 
 func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent string) ([]byte, error) {
+	return encodeIndentWithBaseIndent(ctx, v, prefix, indent, 0)
+}
+
+// encodeIndentWithBaseIndent encodes v as the value nested in baseIndent levels.
+func encodeIndentWithBaseIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent string, baseIndent uint32) ([]byte, error) {
 	b := ctx.Buf[:0]
 	if v == nil {
 		b = encoder.AppendNull(ctx, b)
@@ -285,6 +346,7 @@ func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent str
 
 	p := uintptr(header.ptr)
 	ctx.Init(p, codeSet.CodeLength)
+	ctx.BaseIndent = baseIndent
 	buf, err := encodeRunIndentCode(ctx, b, codeSet, prefix, indent)
 
 	ctx.KeepRefs = append(ctx.KeepRefs, header.ptr)
@@ -2710,3 +2710,502 @@ func TestIssue441(t *testing.T) {
 	assertErr(t, err)
 	assertEq(t, "unexpected result", "{}", string(b))
 }
+
+func TestRedact(t *testing.T) {
+	type Credential struct {
+		User     string `json:"user"`
+		Password string `json:"password,redact"`
+	}
+	type Account struct {
+		ID          int           `json:"id"`
+		Token       string        `json:"token,sensitive"`
+		Card        *int          `json:"card,omitempty,redact"`
+		Credential  Credential    `json:"credential"`
+		Credentials []*Credential `json:"credentials"`
+		Meta        interface{}   `json:"meta"`
+	}
+	card := 1234
+	v := &Account{
+		ID:          1,
+		Token:       "secret",
+		Card:        &card,
+		Credential:  Credential{User: "foo", Password: "pass1"},
+		Credentials: []*Credential{{User: "bar", Password: "pass2"}},
+		Meta:        Credential{User: "baz", Password: "pass3"},
+	}
+	t.Run("disabled", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		expected := `{"id":1,"token":"secret","card":1234,"credential":{"user":"foo","password":"pass1"},"credentials":[{"user":"bar","password":"pass2"}],"meta":{"user":"baz","password":"pass3"}}`
+		assertEq(t, "redact", expected, string(got))
+	})
+	t.Run("enabled", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.Redact())
+		assertErr(t, err)
+		expected := `{"id":1,"token":"***","card":"***","credential":{"user":"foo","password":"***"},"credentials":[{"user":"bar","password":"***"}],"meta":{"user":"baz","password":"***"}}`
+		assertEq(t, "redact", expected, string(got))
+
+		// the cached opcodes without redaction are still used without the option.
+		got, err = json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "redact", false, strings.Contains(string(got), "***"))
+	})
+	t.Run("mask", func(t *testing.T) {
+		got, err := json.MarshalWithOption(Credential{User: "foo", Password: "pass"}, json.RedactWithMask("[redacted]"))
+		assertErr(t, err)
+		assertEq(t, "redact", `{"user":"foo","password":"[redacted]"}`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(Credential{User: "foo", Password: "pass"}, "", "  ", json.Redact())
+		assertErr(t, err)
+		assertEq(t, "redact", "{\n  \"user\": \"foo\",\n  \"password\": \"***\"\n}", string(got))
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		got, err := json.MarshalWithOption(&Account{}, json.Redact())
+		assertErr(t, err)
+		assertEq(t, "redact", `{"id":0,"token":"***","credential":{"user":"","password":"***"},"credentials":null,"meta":null}`, string(got))
+
+		type Empty struct {
+			S  string            `json:"s,omitempty,redact"`
+			I  int               `json:"i,omitempty,redact"`
+			M  map[string]string `json:"m,omitempty,redact"`
+			L  []int             `json:"l,omitempty,redact"`
+			NE string            `json:"ne,omitempty,redact"`
+		}
+		got, err = json.MarshalWithOption(&Empty{NE: "x"}, json.Redact())
+		assertErr(t, err)
+		assertEq(t, "empty", `{"ne":"***"}`, string(got))
+		got, err = json.MarshalWithOption(Empty{S: "a", L: []int{1}}, json.Redact())
+		assertErr(t, err)
+		assertEq(t, "not empty", `{"s":"***","l":"***"}`, string(got))
+		got, err = json.MarshalIndentWithOption(Empty{I: 1}, "", " ", json.Redact())
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"i\": \"***\"\n}", string(got))
+	})
+	t.Run("field query", func(t *testing.T) {
+		query, err := json.BuildFieldQuery("id", "token")
+		assertErr(t, err)
+		ctx := json.SetFieldQueryToContext(context.Background(), query)
+		got, err := json.MarshalContext(ctx, v, json.Redact())
+		assertErr(t, err)
+		assertEq(t, "redact", `{"id":1,"token":"***"}`, string(got))
+	})
+}
+
+func TestGroups(t *testing.T) {
+	type Employee struct {
+		Name   string `json:"name"`
+		Salary int    `json:"salary,groups=admin|hr"`
+		Notes  string `json:"notes,omitempty,groups=admin"`
+	}
+	type Team struct {
+		Lead    *Employee   `json:"lead"`
+		Members []Employee  `json:"members"`
+		Extra   interface{} `json:"extra"`
+	}
+	v := &Team{
+		Lead:    &Employee{Name: "foo", Salary: 100, Notes: "lead"},
+		Members: []Employee{{Name: "bar", Salary: 50}},
+		Extra:   Employee{Name: "baz", Salary: 10},
+	}
+	t.Run("no groups", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "groups", `{"lead":{"name":"foo"},"members":[{"name":"bar"}],"extra":{"name":"baz"}}`, string(got))
+	})
+	t.Run("option", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.Groups("hr"))
+		assertErr(t, err)
+		assertEq(t, "groups", `{"lead":{"name":"foo","salary":100},"members":[{"name":"bar","salary":50}],"extra":{"name":"baz","salary":10}}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.Groups("admin", "hr"))
+		assertErr(t, err)
+		assertEq(t, "groups", `{"lead":{"name":"foo","salary":100,"notes":"lead"},"members":[{"name":"bar","salary":50}],"extra":{"name":"baz","salary":10}}`, string(got))
+
+		// the groups of the previous call are not kept.
+		got, err = json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "groups", `{"lead":{"name":"foo"},"members":[{"name":"bar"}],"extra":{"name":"baz"}}`, string(got))
+	})
+	t.Run("context", func(t *testing.T) {
+		ctx := json.SetGroupsToContext(context.Background(), "admin")
+		assertEq(t, "groups", "admin", strings.Join(json.GroupsFromContext(ctx), ","))
+		got, err := json.MarshalContext(ctx, v.Lead)
+		assertErr(t, err)
+		assertEq(t, "groups", `{"name":"foo","salary":100,"notes":"lead"}`, string(got))
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", " ")
+		assertErr(t, enc.EncodeContext(json.SetGroupsToContext(context.Background(), "unknown"), v.Lead))
+		assertEq(t, "groups", "{\n \"name\": \"foo\"\n}\n", buf.String())
+	})
+	t.Run("redact", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v.Lead, json.Groups("hr"), json.Redact())
+		assertErr(t, err)
+		assertEq(t, "groups", `{"name":"foo","salary":100}`, string(got))
+	})
+}
+
+func TestEncoderToken(t *testing.T) {
+	type T struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+	writeTokens := func(t *testing.T, enc *json.Encoder) {
+		t.Helper()
+		assertErr(t, enc.WriteObjectStart())
+		assertErr(t, enc.WriteKey("items"))
+		assertErr(t, enc.WriteArrayStart())
+		for i := 1; i <= 2; i++ {
+			assertErr(t, enc.WriteValue(&T{A: i, B: "<b>"}))
+		}
+		assertErr(t, enc.WriteEnd())
+		assertErr(t, enc.WriteKey("empty"))
+		assertErr(t, enc.WriteObjectStart())
+		assertErr(t, enc.WriteEnd())
+		assertErr(t, enc.WriteKey("count"))
+		assertErr(t, enc.WriteValue(2))
+		assertErr(t, enc.WriteEnd())
+	}
+	t.Run("compact", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		writeTokens(t, enc)
+		assertErr(t, enc.WriteValue(nil))
+		assertEq(t, "token", "{\"items\":[{\"a\":1,\"b\":\"\\u003cb\\u003e\"},{\"a\":2,\"b\":\"\\u003cb\\u003e\"}],\"empty\":{},\"count\":2}\nnull\n", buf.String())
+	})
+	t.Run("indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		writeTokens(t, enc)
+		assertEq(t, "token", strings.Join([]string{
+			"{",
+			`  "items": [`,
+			"    {",
+			`      "a": 1,`,
+			`      "b": "<b>"`,
+			"    },",
+			"    {",
+			`      "a": 2,`,
+			`      "b": "<b>"`,
+			"    }",
+			"  ],",
+			`  "empty": {},`,
+			`  "count": 2`,
+			"}",
+			"",
+		}, "\n"), buf.String())
+	})
+	t.Run("colorize", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.WriteObjectStart())
+		assertErr(t, enc.WriteKey("a", json.Colorize(json.DefaultColorScheme)))
+		assertErr(t, enc.WriteValue(1, json.Colorize(json.DefaultColorScheme)))
+		assertErr(t, enc.WriteEnd())
+		expected, err := json.MarshalWithOption(struct {
+			A int `json:"a"`
+		}{A: 1}, json.Colorize(json.DefaultColorScheme))
+		assertErr(t, err)
+		assertEq(t, "token", string(expected)+"\n", buf.String())
+	})
+	t.Run("nesting error", func(t *testing.T) {
+		enc := json.NewEncoder(io.Discard)
+		var syntaxErr *json.SyntaxError
+		if err := enc.WriteKey("a"); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		if err := enc.WriteEnd(); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		assertErr(t, enc.WriteObjectStart())
+		if err := enc.WriteValue(1); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		if err := enc.WriteArrayStart(); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		assertErr(t, enc.WriteKey("a"))
+		if err := enc.WriteKey("b"); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		if err := enc.WriteEnd(); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		assertErr(t, enc.WriteArrayStart())
+		if err := enc.WriteKey("b"); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		if err := enc.Encode(1); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		if err := enc.EncodeContext(context.Background(), 1); !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected syntax error but got %v", err)
+		}
+		assertErr(t, enc.WriteEnd())
+		assertErr(t, enc.WriteEnd())
+		assertErr(t, enc.Encode(1))
+	})
+}
+
+type recordWriter struct {
+	writes []string
+}
+
+func (w *recordWriter) Write(b []byte) (int, error) {
+	w.writes = append(w.writes, string(b))
+	return len(b), nil
+}
+
+func (w *recordWriter) String() string {
+	return strings.Join(w.writes, "")
+}
+
+func TestEncodeStream(t *testing.T) {
+	type T struct {
+		ID int `json:"id"`
+	}
+	seq := func(n int) func(yield func(T) bool) {
+		return func(yield func(T) bool) {
+			for i := 1; i <= n; i++ {
+				if !yield(T{ID: i}) {
+					return
+				}
+			}
+		}
+	}
+	t.Run("chan", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+		got, err := json.Marshal(ch)
+		assertErr(t, err)
+		assertEq(t, "stream", `[1,2,3]`, string(got))
+	})
+	t.Run("iterator", func(t *testing.T) {
+		got, err := json.Marshal(seq(2))
+		assertErr(t, err)
+		assertEq(t, "stream", `[{"id":1},{"id":2}]`, string(got))
+
+		got, err = json.Marshal(seq(0))
+		assertErr(t, err)
+		assertEq(t, "stream", `[]`, string(got))
+	})
+	t.Run("field", func(t *testing.T) {
+		type Response struct {
+			Items  func(yield func(T) bool) `json:"items"`
+			Names  <-chan string            `json:"names"`
+			Nil    chan int                 `json:"nil"`
+			PtrSeq *json.Stream[int]        `json:"ptr"`
+			Count  int                      `json:"count"`
+		}
+		names := make(chan string, 1)
+		names <- "a"
+		close(names)
+		ptrSeq := json.Stream[int](func(yield func(int) bool) error {
+			yield(1)
+			return nil
+		})
+		v := &Response{Items: seq(2), Names: names, PtrSeq: &ptrSeq, Count: 2}
+		got, err := json.MarshalIndent(v, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "stream", strings.Join([]string{
+			"{",
+			`  "items": [`,
+			"    {",
+			`      "id": 1`,
+			"    },",
+			"    {",
+			`      "id": 2`,
+			"    }",
+			"  ],",
+			`  "names": [`,
+			`    "a"`,
+			"  ],",
+			`  "nil": null,`,
+			`  "ptr": [`,
+			"    1",
+			"  ],",
+			`  "count": 2`,
+			"}",
+		}, "\n"), string(got))
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		type Response struct {
+			Ch    chan int                 `json:"ch,omitempty"`
+			Items func(yield func(T) bool) `json:"items,omitempty"`
+			Count int                      `json:"count"`
+			Seq   json.Stream[int]         `json:"seq,omitempty"`
+		}
+		got, err := json.Marshal(Response{})
+		assertErr(t, err)
+		assertEq(t, "nil", `{"count":0}`, string(got))
+		got, err = json.Marshal(&Response{Items: seq(1), Seq: func(yield func(int) bool) error { return nil }})
+		assertErr(t, err)
+		assertEq(t, "not nil", `{"items":[{"id":1}],"count":0,"seq":[]}`, string(got))
+	})
+	t.Run("error", func(t *testing.T) {
+		expected := fmt.Errorf("failed")
+		s := json.Stream[int](func(yield func(int) bool) error {
+			if !yield(1) {
+				return nil
+			}
+			return expected
+		})
+		_, err := json.Marshal(s)
+		if !errors.Is(err, expected) {
+			t.Fatalf("expected %v but got %v", expected, err)
+		}
+		_, err = json.Marshal(json.Stream[func()](func(yield func(func()) bool) error {
+			if yield(func() {}) {
+				t.Fatal("the iteration is not stopped after the error")
+			}
+			return nil
+		}))
+		var unsupported *json.UnsupportedTypeError
+		if !errors.As(err, &unsupported) {
+			t.Fatalf("expected unsupported type error but got %v", err)
+		}
+		_, err = json.Marshal(make(chan<- int))
+		if !errors.As(err, &unsupported) {
+			t.Fatalf("expected unsupported type error but got %v", err)
+		}
+	})
+	t.Run("flush", func(t *testing.T) {
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		s := json.Stream[T](func(yield func(T) bool) error {
+			for i := 1; i <= 3; i++ {
+				if !yield(T{ID: i}) {
+					return nil
+				}
+				// the element has been written before producing the next one.
+				if !strings.Contains(w.String(), fmt.Sprintf(`{"id":%d`, i)) {
+					t.Fatalf("element %d is not written: %q", i, w.String())
+				}
+			}
+			return nil
+		})
+		assertErr(t, enc.Encode(struct {
+			Items json.Stream[T] `json:"items"`
+		}{Items: s}))
+		// the sorted map is written after all of its values are encoded.
+		assertErr(t, enc.Encode(map[string]interface{}{"b": seq(1), "a": seq(2)}))
+		assertEq(t, "stream", "{\"items\":[{\"id\":1},{\"id\":2},{\"id\":3}]}\n{\"a\":[{\"id\":1},{\"id\":2}],\"b\":[{\"id\":1}]}\n", w.String())
+	})
+}
+
+type errorWriter struct {
+	limit int
+	err   error
+}
+
+func (w *errorWriter) Write(b []byte) (int, error) {
+	if w.limit < len(b) {
+		return 0, w.err
+	}
+	w.limit -= len(b)
+	return len(b), nil
+}
+
+func TestEncoderFlushThreshold(t *testing.T) {
+	type T struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	v := make([]T, 1000)
+	m := map[string]T{}
+	for i := range v {
+		v[i] = T{ID: i, Name: "name"}
+		m[strconv.Itoa(i)] = v[i]
+	}
+	for _, indent := range []bool{false, true} {
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		var expected []byte
+		var err error
+		if indent {
+			enc.SetIndent("", "  ")
+			expected, err = json.MarshalIndent(v, "", "  ")
+		} else {
+			expected, err = json.Marshal(v)
+		}
+		assertErr(t, err)
+		assertErr(t, enc.EncodeWithOption(v, json.FlushThreshold(256)))
+		assertEq(t, "flush", string(expected)+"\n", w.String())
+		if len(w.writes) < 10 {
+			t.Fatalf("expected to be flushed during encoding but written %d times", len(w.writes))
+		}
+		for _, written := range w.writes {
+			if len(written) > 512 {
+				t.Fatalf("unexpected size of write: %d", len(written))
+			}
+		}
+	}
+	t.Run("unordered map", func(t *testing.T) {
+		var w recordWriter
+		enc := json.NewEncoder(&w)
+		assertErr(t, enc.EncodeWithOption(m, json.FlushThreshold(256), json.UnorderedMap()))
+		if len(w.writes) < 10 {
+			t.Fatalf("expected to be flushed during encoding but written %d times", len(w.writes))
+		}
+		var got map[string]T
+		assertErr(t, json.Unmarshal([]byte(w.String()), &got))
+		assertEq(t, "flush", len(m), len(got))
+	})
+	t.Run("writer error", func(t *testing.T) {
+		expected := fmt.Errorf("failed to write")
+		enc := json.NewEncoder(&errorWriter{limit: 1024, err: expected})
+		if err := enc.EncodeWithOption(v, json.FlushThreshold(256)); !errors.Is(err, expected) {
+			t.Fatalf("expected %v but got %v", expected, err)
+		}
+	})
+	t.Run("marshal", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v[:2], json.FlushThreshold(1))
+		assertErr(t, err)
+		assertEq(t, "flush", `[{"id":0,"name":"name"},{"id":1,"name":"name"}]`, string(got))
+	})
+}
+
+func TestEncodeOptionParity(t *testing.T) {
+	v := map[string]interface{}{"a": []int{1, 2}, "b": "<>"}
+	expected, err := json.MarshalIndent(v, "", "  ")
+	assertErr(t, err)
+
+	got, err := json.MarshalWithOption(v, json.IndentWith("", "  "))
+	assertErr(t, err)
+	assertEq(t, "marshal", string(expected), string(got))
+	got, err = json.MarshalContext(context.Background(), v, json.IndentWith("", "  "))
+	assertErr(t, err)
+	assertEq(t, "marshal context", string(expected), string(got))
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	assertErr(t, enc.EncodeWithOption(v, json.IndentWith("", "  "), json.DisableHTMLEscape()))
+	assertErr(t, enc.EncodeWithOption(v, json.EscapeHTML()))
+	assertEq(t, "encoder", strings.Replace(string(expected), `\u003c\u003e`, "<>", 1)+"\n"+`{"a":[1,2],"b":"\u003c\u003e"}`+"\n", buf.String())
+
+	buf.Reset()
+	enc = json.NewEncoder(&buf, json.IndentWith("", "  "), json.DisableHTMLEscape())
+	assertErr(t, enc.Encode(v))
+	assertErr(t, enc.EncodeWithOption(v, json.IndentWith("", ""), json.EscapeHTML()))
+	assertEq(t, "persistent", strings.Replace(string(expected), `\u003c\u003e`, "<>", 1)+"\n"+`{"a":[1,2],"b":"\u003c\u003e"}`+"\n", buf.String())
+
+	buf.Reset()
+	enc = json.NewEncoder(&buf)
+	enc.SetOptions(json.IndentWith("", "  "), json.Colorize(json.DefaultColorScheme))
+	assertErr(t, enc.WriteObjectStart())
+	assertErr(t, enc.WriteKey("a"))
+	assertErr(t, enc.WriteValue(1))
+	assertErr(t, enc.WriteEnd())
+	expected, err = json.MarshalIndentWithOption(struct {
+		A int `json:"a"`
+	}{A: 1}, "", "  ", json.Colorize(json.DefaultColorScheme))
+	assertErr(t, err)
+	assertEq(t, "token", string(expected)+"\n", buf.String())
+}
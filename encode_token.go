@@ -0,0 +1,193 @@
+package json
+
+import (
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// encodeTokenScope is the object or array opened by WriteObjectStart or WriteArrayStart.
+type encodeTokenScope struct {
+	delim  byte
+	length int
+	hasKey bool
+}
+
+// WriteObjectStart writes the beginning of an object.
+// Write its members by pairs of WriteKey and the value, then close it by WriteEnd.
+func (e *Encoder) WriteObjectStart() error {
+	return e.writeStart('{')
+}
+
+// WriteArrayStart writes the beginning of an array.
+// Write its elements as values, then close it by WriteEnd.
+func (e *Encoder) WriteArrayStart() error {
+	return e.writeStart('[')
+}
+
+func (e *Encoder) writeStart(delim byte) error {
+	ctx := encoder.TakeRuntimeContext()
+	defer encoder.ReleaseRuntimeContext(ctx)
+
+	b, err := e.appendValueSeparator(ctx.Buf[:0])
+	if err != nil {
+		return err
+	}
+	e.scopes = append(e.scopes, encodeTokenScope{delim: delim})
+	b = append(b, delim)
+	ctx.Buf = b
+	return e.writeToken(b)
+}
+
+// WriteKey writes the key of the next member in the object opened by WriteObjectStart.
+// The key is escaped and colorized in the same way as the keys of the values encoded with optFuncs.
+func (e *Encoder) WriteKey(key string, optFuncs ...EncodeOptionFunc) error {
+	if len(e.scopes) == 0 || e.scopes[len(e.scopes)-1].delim != '{' {
+		return e.tokenError("json: WriteKey called outside of object")
+	}
+	scope := &e.scopes[len(e.scopes)-1]
+	if scope.hasKey {
+		return e.tokenError("json: WriteKey called before writing the value of the previous key")
+	}
+	ctx := encoder.TakeRuntimeContext()
+	defer encoder.ReleaseRuntimeContext(ctx)
+	ctx.Option.Flag = 0
+	e.setOption(ctx, optFuncs...)
+
+	b := e.appendElemSeparator(ctx.Buf[:0], scope)
+	if (ctx.Option.Flag & encoder.ColorizeOption) != 0 {
+		format := ctx.Option.ColorScheme.ObjectKey
+		b = append(b, format.Header...)
+		b = encoder.AppendString(ctx, b, key)
+		b = append(b, format.Footer...)
+	} else {
+		b = encoder.AppendString(ctx, b, key)
+	}
+	b = append(b, ':')
+	if e.enabledIndent {
+		b = append(b, ' ')
+	}
+	scope.hasKey = true
+	ctx.Buf = b
+	return e.writeToken(b)
+}
+
+// WriteEnd writes the end of the innermost object or array.
+func (e *Encoder) WriteEnd() error {
+	if len(e.scopes) == 0 {
+		return e.tokenError("json: WriteEnd called without opening object or array")
+	}
+	scope := e.scopes[len(e.scopes)-1]
+	if scope.hasKey {
+		return e.tokenError("json: WriteEnd called before writing the value of the last key")
+	}
+	e.scopes = e.scopes[:len(e.scopes)-1]
+
+	ctx := encoder.TakeRuntimeContext()
+	defer encoder.ReleaseRuntimeContext(ctx)
+
+	b := ctx.Buf[:0]
+	if e.enabledIndent && scope.length > 0 {
+		b = e.appendTokenIndent(b, len(e.scopes))
+	}
+	if scope.delim == '{' {
+		b = append(b, '}')
+	} else {
+		b = append(b, ']')
+	}
+	if len(e.scopes) == 0 {
+		b = append(b, '\n')
+	}
+	ctx.Buf = b
+	return e.writeToken(b)
+}
+
+// WriteValue writes the JSON encoding of v as the next value.
+// It's encoded in the same way as Encode, so the compiled encoding of the type is used.
+// The value at the top level is followed by a newline character like Encode.
+func (e *Encoder) WriteValue(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	defer encoder.ReleaseRuntimeContext(ctx)
+	ctx.Option.Flag = 0
+	e.setOption(ctx, optFuncs...)
+	ctx.Writer = (*encoderWriter)(e)
+
+	// the separator is written first, because the elements of the stream are written during encoding.
+	sep, err := e.appendValueSeparator(ctx.MarshalBuf[:0])
+	if err != nil {
+		return err
+	}
+	ctx.MarshalBuf = sep
+	if len(sep) > 0 {
+		if err := e.writeToken(sep); err != nil {
+			return err
+		}
+	}
+	var buf []byte
+	if e.enabledIndent {
+		buf, err = encodeIndentWithBaseIndent(ctx, v, e.prefix, e.indentStr, uint32(len(e.scopes)))
+	} else {
+		buf, err = encode(ctx, v)
+	}
+	if err != nil {
+		return err
+	}
+	if e.enabledIndent {
+		buf = buf[:len(buf)-2]
+	} else {
+		buf = buf[:len(buf)-1]
+	}
+	if len(e.scopes) == 0 {
+		buf = append(buf, '\n')
+	}
+	ctx.Buf = buf
+	return e.writeToken(buf)
+}
+
+// appendValueSeparator appends the separator written before the next value,
+// and reports the error if a value is not allowed at the current position.
+func (e *Encoder) appendValueSeparator(b []byte) ([]byte, error) {
+	if len(e.scopes) == 0 {
+		return b, nil
+	}
+	scope := &e.scopes[len(e.scopes)-1]
+	if scope.delim == '{' {
+		if !scope.hasKey {
+			return nil, e.tokenError("json: value written before the key in object")
+		}
+		scope.hasKey = false
+		scope.length++
+		return b, nil
+	}
+	return e.appendElemSeparator(b, scope), nil
+}
+
+func (e *Encoder) appendElemSeparator(b []byte, scope *encodeTokenScope) []byte {
+	if scope.length > 0 {
+		b = append(b, ',')
+	}
+	if scope.delim == '[' {
+		scope.length++
+	}
+	if e.enabledIndent {
+		b = e.appendTokenIndent(b, len(e.scopes))
+	}
+	return b
+}
+
+func (e *Encoder) appendTokenIndent(b []byte, depth int) []byte {
+	b = append(b, '\n')
+	b = append(b, e.prefix...)
+	for i := 0; i < depth; i++ {
+		b = append(b, e.indentStr...)
+	}
+	return b
+}
+
+func (e *Encoder) writeToken(b []byte) error {
+	_, err := (*encoderWriter)(e).Write(b)
+	return err
+}
+
+func (e *Encoder) tokenError(msg string) error {
+	return errors.ErrSyntax(msg, e.written)
+}
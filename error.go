@@ -38,4 +38,8 @@ type UnsupportedTypeError = errors.UnsupportedTypeError
 
 type UnsupportedValueError = errors.UnsupportedValueError
 
+// A CanceledError is returned when decoding from a Decoder is aborted because the context is done.
+// It wraps the error of the context, so it can be checked by errors.Is(err, context.Canceled).
+type CanceledError = errors.CanceledError
+
 type PathError = errors.PathError
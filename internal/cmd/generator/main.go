@@ -201,6 +201,9 @@ func (t OpType) FieldToOmitEmptyField() OpType {
 		createOpType("RecursivePtr", "Op"),
 		createOpType("RecursiveEnd", "Op"),
 		createOpType("InterfaceEnd", "Op"),
+		createOpType("Redact", "Op"),
+		createOpType("Stream", "Op"),
+		createOpType("StreamPtr", "Op"),
 	}
 	for _, typ := range primitiveTypesUpper {
 		typ := typ
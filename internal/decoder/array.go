@@ -50,7 +50,11 @@ func (d *arrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 		case '[':
 			idx := 0
 			s.cursor++
-			if s.skipWhiteSpace() == ']' {
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == ']' {
 				for idx < d.alen {
 					*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
 					idx++
@@ -58,18 +62,26 @@ func (d *arrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 				s.cursor++
 				return nil
 			}
+			relaxed := isRelaxed(s.Option)
 			for {
+				if err := s.checkContext(); err != nil {
+					return err
+				}
 				if idx < d.alen {
 					if err := d.valueDecoder.DecodeStream(s, depth, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size)); err != nil {
 						return err
 					}
 				} else {
-					if err := s.skipValue(depth); err != nil {
+					if err := s.skipValueWithOption(depth); err != nil {
 						return err
 					}
 				}
 				idx++
-				switch s.skipWhiteSpace() {
+				c, err := s.skipWhiteSpaceWithOption()
+				if err != nil {
+					return err
+				}
+				switch c {
 				case ']':
 					for idx < d.alen {
 						*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
@@ -79,6 +91,21 @@ func (d *arrayDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 					return nil
 				case ',':
 					s.cursor++
+					if relaxed {
+						c, err := s.skipWhiteSpaceWithOption()
+						if err != nil {
+							return err
+						}
+						if c == ']' {
+							// the trailing comma is allowed.
+							for idx < d.alen {
+								*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
+								idx++
+							}
+							s.cursor++
+							return nil
+						}
+					}
 					continue
 				case nul:
 					if s.read() {
@@ -124,8 +151,10 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 			return cursor, nil
 		case '[':
 			idx := 0
-			cursor++
-			cursor = skipWhiteSpace(buf, cursor)
+			cursor, err := skipWhiteSpaceWithOption(ctx, cursor+1)
+			if err != nil {
+				return 0, err
+			}
 			if buf[cursor] == ']' {
 				for idx < d.alen {
 					*(*unsafe.Pointer)(unsafe.Pointer(uintptr(p) + uintptr(idx)*d.size)) = d.zeroValue
@@ -134,6 +163,7 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 				cursor++
 				return cursor, nil
 			}
+			relaxed := isRelaxed(ctx.Option)
 			for {
 				if idx < d.alen {
 					c, err := d.valueDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(uintptr(p)+uintptr(idx)*d.size))
@@ -142,14 +172,22 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 					}
 					cursor = c
 				} else {
-					c, err := skipValue(buf, cursor, depth)
+					c, err := skipValueWithOption(ctx, cursor, depth)
 					if err != nil {
 						return 0, err
 					}
 					cursor = c
 				}
 				idx++
-				cursor = skipWhiteSpace(buf, cursor)
+				cursor, err = skipWhiteSpaceWithOption(ctx, cursor)
+				if err != nil {
+					return 0, err
+				}
+				if relaxed {
+					if cursor, err = skipTrailingComma(buf, cursor, ']'); err != nil {
+						return 0, err
+					}
+				}
 				switch buf[cursor] {
 				case ']':
 					for idx < d.alen {
@@ -160,6 +198,11 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 					return cursor, nil
 				case ',':
 					cursor++
+					if relaxed {
+						if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+							return 0, err
+						}
+					}
 					continue
 				default:
 					return 0, errors.ErrInvalidCharacter(buf[cursor], "array", cursor)
@@ -23,6 +23,9 @@ func AssignValue(src, dst reflect.Value) error {
 }
 
 func castValue(t reflect.Type, v reflect.Value) (reflect.Value, error) {
+	if t == valueReflectType || t == valuePtrReflectType {
+		return castJSONValue(t, v)
+	}
 	switch t.Kind() {
 	case reflect.Int:
 		vv, err := castInt(v)
@@ -436,3 +439,19 @@ func castStruct(t reflect.Type, v reflect.Value) (reflect.Value, error) {
 		return nilValue, fmt.Errorf("failed to cast to struct from %s", v.Type().Kind())
 	}
 }
+
+// castJSONValue converts v into Value through its JSON encoding, or into *Value for the pointer type.
+func castJSONValue(t reflect.Type, v reflect.Value) (reflect.Value, error) {
+	var src interface{}
+	if v.IsValid() {
+		src = v.Interface()
+	}
+	value, err := ValueOf(src)
+	if err != nil {
+		return nilValue, err
+	}
+	if t.Kind() == reflect.Ptr {
+		return reflect.ValueOf(value), nil
+	}
+	return reflect.ValueOf(*value), nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding"
 	"encoding/json"
 	"reflect"
+	"strconv"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
@@ -19,6 +20,7 @@ type interfaceDecoder struct {
 	mapDecoder    *mapDecoder
 	floatDecoder  *floatDecoder
 	numberDecoder *numberDecoder
+	intDecoder    *interfaceIntDecoder
 	stringDecoder *stringDecoder
 }
 
@@ -33,6 +35,7 @@ func newEmptyInterfaceDecoder(structName, fieldName string) *interfaceDecoder {
 		numberDecoder: newNumberDecoder(structName, fieldName, func(p unsafe.Pointer, v json.Number) {
 			*(*interface{})(p) = v
 		}),
+		intDecoder:    newInterfaceIntDecoder(structName, fieldName),
 		stringDecoder: newStringDecoder(structName, fieldName),
 	}
 	ifaceDecoder.sliceDecoder = newSliceDecoder(
@@ -81,17 +84,100 @@ func newInterfaceDecoder(typ *runtime.Type, structName, fieldName string) *inter
 		numberDecoder: newNumberDecoder(structName, fieldName, func(p unsafe.Pointer, v json.Number) {
 			*(*interface{})(p) = v
 		}),
+		intDecoder:    newInterfaceIntDecoder(structName, fieldName),
 		stringDecoder: stringDecoder,
 	}
 }
 
-func (d *interfaceDecoder) numDecoder(s *Stream) Decoder {
-	if s.UseNumber {
+func (d *interfaceDecoder) numDecoder(opt *Option) Decoder {
+	if opt.Flags&IntegersAsInt64Option != 0 {
+		return d.intDecoder
+	}
+	if opt.Flags&UseNumberOption != 0 {
 		return d.numberDecoder
 	}
 	return d.floatDecoder
 }
 
+// interfaceIntDecoder decodes an integer into an interface{} as an int64,
+// or as a uint64 and then a Number when it overflows.
+// The other numbers are decoded as float64, or as Number with UseNumberOption.
+type interfaceIntDecoder struct {
+	floatDecoder *floatDecoder
+}
+
+func newInterfaceIntDecoder(structName, fieldName string) *interfaceIntDecoder {
+	return &interfaceIntDecoder{
+		floatDecoder: newFloatDecoder(structName, fieldName, nil),
+	}
+}
+
+func (d *interfaceIntDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	bytes, err := d.floatDecoder.decodeStreamByte(s)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return nil
+	}
+	v, err := interfaceNumber(bytes, s.Option)
+	if err != nil {
+		return errors.ErrSyntax(err.Error(), s.totalOffset())
+	}
+	*(*interface{})(p) = v
+	return nil
+}
+
+func (d *interfaceIntDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	bytes, c, err := d.floatDecoder.decodeByte(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if bytes == nil {
+		return c, nil
+	}
+	cursor = c
+	if !validEndNumberChar[buf[cursor]] {
+		return 0, errors.ErrUnexpectedEndOfJSON("float", cursor)
+	}
+	v, err := interfaceNumber(bytes, ctx.Option)
+	if err != nil {
+		return 0, errors.ErrSyntax(err.Error(), cursor)
+	}
+	**(**interface{})(unsafe.Pointer(&p)) = v
+	return cursor, nil
+}
+
+func (d *interfaceIntDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	return d.floatDecoder.DecodePath(ctx, cursor, depth)
+}
+
+func interfaceNumber(b []byte, opt *Option) (interface{}, error) {
+	s := *(*string)(unsafe.Pointer(&b))
+	if bytes.IndexAny(b, ".eE") < 0 {
+		i64, err := strconv.ParseInt(s, 10, 64)
+		if err == nil {
+			return i64, nil
+		}
+		if err.(*strconv.NumError).Err != strconv.ErrRange {
+			return nil, err
+		}
+		if u64, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u64, nil
+		}
+		return json.Number(string(b)), nil
+	}
+	f64, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Flags&UseNumberOption != 0 {
+		return json.Number(string(b)), nil
+	}
+	return f64, nil
+}
+
 var (
 	emptyInterfaceType = runtime.Type2RType(reflect.TypeOf((*interface{})(nil)).Elem())
 	EmptyInterfaceType = emptyInterfaceType
@@ -213,6 +299,9 @@ func (d *interfaceDecoder) decodeStreamEmptyInterface(s *Stream, depth int64, p
 	for {
 		switch c {
 		case '{':
+			if s.Option.Flags&OrderedObjectOption != 0 {
+				return d.decodeStreamOrderedObject(s, depth, p)
+			}
 			var v map[string]interface{}
 			ptr := unsafe.Pointer(&v)
 			if err := d.mapDecoder.DecodeStream(s, depth, ptr); err != nil {
@@ -229,7 +318,17 @@ func (d *interfaceDecoder) decodeStreamEmptyInterface(s *Stream, depth int64, p
 			*(*interface{})(p) = v
 			return nil
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			return d.numDecoder(s).DecodeStream(s, depth, p)
+			return d.numDecoder(s.Option).DecodeStream(s, depth, p)
+		case '\'':
+			if !isRelaxed(s.Option) {
+				break
+			}
+			bytes, err := s.decodeSingleQuotedString()
+			if err != nil {
+				return err
+			}
+			*(*interface{})(p) = string(bytes)
+			return nil
 		case '"':
 			s.cursor++
 			start := s.cursor
@@ -405,6 +504,9 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 	cursor = skipWhiteSpace(buf, cursor)
 	switch buf[cursor] {
 	case '{':
+		if ctx.Option.Flags&OrderedObjectOption != 0 {
+			return d.decodeOrderedObject(ctx, cursor, depth, p)
+		}
 		var v map[string]interface{}
 		ptr := unsafe.Pointer(&v)
 		cursor, err := d.mapDecoder.Decode(ctx, cursor, depth, ptr)
@@ -423,7 +525,7 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 		**(**interface{})(unsafe.Pointer(&p)) = v
 		return cursor, nil
 	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		return d.floatDecoder.Decode(ctx, cursor, depth, p)
+		return d.numDecoder(ctx.Option).Decode(ctx, cursor, depth, p)
 	case '"':
 		var v string
 		ptr := unsafe.Pointer(&v)
@@ -433,6 +535,16 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 		}
 		**(**interface{})(unsafe.Pointer(&p)) = v
 		return cursor, nil
+	case '\'':
+		if !isRelaxed(ctx.Option) {
+			break
+		}
+		v, cursor, err := decodeSingleQuotedString(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		**(**interface{})(unsafe.Pointer(&p)) = string(v)
+		return cursor, nil
 	case 't':
 		if err := validateTrue(buf, cursor); err != nil {
 			return 0, err
@@ -501,6 +613,20 @@ func (d *interfaceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64)
 		return d.mapDecoder.DecodePath(ctx, cursor, depth)
 	case '[':
 		return d.sliceDecoder.DecodePath(ctx, cursor, depth)
+	}
+	paths, c, err := d.decodeScalarPath(ctx, cursor, depth)
+	if err != nil {
+		return nil, c, err
+	}
+	if ctx.Option.Path.recursiveTarget() {
+		ctx.Option.addPathMatch(cursor, c)
+	}
+	return paths, c, nil
+}
+
+func (d *interfaceDecoder) decodeScalarPath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	buf := ctx.Buf
+	switch buf[cursor] {
 	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		return d.floatDecoder.DecodePath(ctx, cursor, depth)
 	case '"':
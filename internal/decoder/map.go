@@ -88,27 +88,48 @@ func (d *mapDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) erro
 		mapValue = makemap(d.mapType, 0)
 	}
 	s.cursor++
-	if s.skipWhiteSpace() == '}' {
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	if c == '}' {
 		*(*unsafe.Pointer)(p) = mapValue
 		s.cursor++
 		return nil
 	}
+	relaxed := isRelaxed(s.Option)
 	for {
+		if err := s.checkContext(); err != nil {
+			return err
+		}
 		k := unsafe_New(d.keyType)
-		if err := d.keyDecoder.DecodeStream(s, depth, k); err != nil {
+		if relaxed && isRelaxedKeyStart(s.char()) {
+			if err := d.decodeRelaxedKeyStream(s, depth, k); err != nil {
+				return err
+			}
+		} else if err := d.keyDecoder.DecodeStream(s, depth, k); err != nil {
+			return err
+		}
+		if _, err := s.skipWhiteSpaceWithOption(); err != nil {
 			return err
 		}
-		s.skipWhiteSpace()
 		if !s.equalChar(':') {
 			return errors.ErrExpected("colon after object key", s.totalOffset())
 		}
 		s.cursor++
+		if relaxed {
+			if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+				return err
+			}
+		}
 		v := unsafe_New(d.valueType)
 		if err := d.valueDecoder.DecodeStream(s, depth, v); err != nil {
 			return err
 		}
 		d.mapassign(d.mapType, mapValue, k, v)
-		s.skipWhiteSpace()
+		if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
 		if s.equalChar('}') {
 			**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 			s.cursor++
@@ -118,6 +139,18 @@ func (d *mapDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) erro
 			return errors.ErrExpected("comma after object value", s.totalOffset())
 		}
 		s.cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == '}' {
+				**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
+				s.cursor++
+				return nil
+			}
+		}
 	}
 }
 
@@ -146,7 +179,10 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 		return 0, errors.ErrExpected("{ character for map value", cursor)
 	}
 	cursor++
-	cursor = skipWhiteSpace(buf, cursor)
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
 	mapValue := *(*unsafe.Pointer)(p)
 	if mapValue == nil {
 		mapValue = makemap(d.mapType, 0)
@@ -156,24 +192,41 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 		cursor++
 		return cursor, nil
 	}
+	relaxed := isRelaxed(ctx.Option)
 	for {
 		k := unsafe_New(d.keyType)
-		keyCursor, err := d.keyDecoder.Decode(ctx, cursor, depth, k)
+		var keyCursor int64
+		if relaxed && isRelaxedKeyStart(buf[cursor]) {
+			keyCursor, err = d.decodeRelaxedKey(ctx, cursor, depth, k)
+		} else {
+			keyCursor, err = d.keyDecoder.Decode(ctx, cursor, depth, k)
+		}
+		if err != nil {
+			return 0, err
+		}
+		cursor, err = skipWhiteSpaceWithOption(ctx, keyCursor)
 		if err != nil {
 			return 0, err
 		}
-		cursor = skipWhiteSpace(buf, keyCursor)
 		if buf[cursor] != ':' {
 			return 0, errors.ErrExpected("colon after object key", cursor)
 		}
 		cursor++
+		if relaxed {
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+		}
 		v := unsafe_New(d.valueType)
 		valueCursor, err := d.valueDecoder.Decode(ctx, cursor, depth, v)
 		if err != nil {
 			return 0, err
 		}
 		d.mapassign(d.mapType, mapValue, k, v)
-		cursor = skipWhiteSpace(buf, valueCursor)
+		cursor, err = skipWhiteSpaceWithOption(ctx, valueCursor)
+		if err != nil {
+			return 0, err
+		}
 		if buf[cursor] == '}' {
 			**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 			cursor++
@@ -183,7 +236,43 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 			return 0, errors.ErrExpected("comma after object value", cursor)
 		}
 		cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+			if buf[cursor] == '}' {
+				**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
+				return cursor + 1, nil
+			}
+		}
+	}
+}
+
+// decodeRelaxedKey decodes the unquoted or single-quoted key of the relaxed syntax at cursor into k.
+// The key is converted into the double-quoted JSON string for the key decoder like wrappedStringDecoder does.
+func (d *mapDecoder) decodeRelaxedKey(ctx *RuntimeContext, cursor, depth int64, k unsafe.Pointer) (int64, error) {
+	quoted, end, err := quoteRelaxedKey(ctx.Buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	buf := ctx.Buf
+	ctx.Buf = quoted
+	_, err = d.keyDecoder.Decode(ctx, 0, depth, k)
+	ctx.Buf = buf
+	if err != nil {
+		return 0, err
+	}
+	return end, nil
+}
+
+func (d *mapDecoder) decodeRelaxedKeyStream(s *Stream, depth int64, k unsafe.Pointer) error {
+	quoted, err := s.quoteRelaxedKey()
+	if err != nil {
+		return err
 	}
+	_, err = d.keyDecoder.Decode(&RuntimeContext{Buf: quoted, Option: s.Option}, 0, depth, k)
+	return err
 }
 
 func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
@@ -241,6 +330,7 @@ func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]b
 			return nil, 0, err
 		}
 		if found {
+			ctx.Option.pushPathField(key)
 			if child != nil {
 				oldPath := ctx.Option.Path.node
 				ctx.Option.Path.node = child
@@ -258,8 +348,10 @@ func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]b
 					return nil, 0, err
 				}
 				ret = append(ret, buf[start:end])
+				ctx.Option.addPathMatch(start, end)
 				cursor = end
 			}
+			ctx.Option.popPathLocation()
 		} else {
 			c, err := skipValue(buf, cursor, depth)
 			if err != nil {
@@ -2,16 +2,65 @@ package decoder
 
 import "context"
 
-type OptionFlags uint8
+type OptionFlags uint16
 
 const (
 	FirstWinOption OptionFlags = 1 << iota
 	ContextOption
 	PathOption
+	PathMatchOption
+	UseNumberOption
+	DisallowUnknownFieldsOption
+	RelaxedOption
+	OrderedObjectOption
+	IntegersAsInt64Option
 )
 
 type Option struct {
 	Flags   OptionFlags
 	Context context.Context
 	Path    *Path
+
+	// matches holds the values matched by Path and location holds the position currently decoded.
+	// They are only populated when PathMatchOption is set.
+	matches  []PathMatch
+	location []pathLocation
+}
+
+type pathLocation struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+func (o *Option) pushPathField(key []byte) {
+	if o.Flags&PathMatchOption == 0 {
+		return
+	}
+	o.location = append(o.location, pathLocation{field: string(key)})
+}
+
+func (o *Option) pushPathIndex(idx int) {
+	if o.Flags&PathMatchOption == 0 {
+		return
+	}
+	o.location = append(o.location, pathLocation{index: idx, isIndex: true})
+}
+
+func (o *Option) popPathLocation() {
+	if o.Flags&PathMatchOption == 0 {
+		return
+	}
+	o.location = o.location[:len(o.location)-1]
+}
+
+func (o *Option) addPathMatch(start, end int64) {
+	if o.Flags&PathMatchOption == 0 {
+		return
+	}
+	o.matches = append(o.matches, PathMatch{
+		NormalizedPath: normalizedPath(o.location),
+		Start:          start,
+		End:            end,
+	})
 }
@@ -0,0 +1,412 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// OrderedObject is a JSON object that keeps the order of the members.
+// It's made for the JSON objects decoded into interface{} when OrderedObjectOption is set, and is encoded in the same order.
+// The lookup by key is a linear search, so it's intended for the objects of moderate size.
+type OrderedObject []OrderedMember
+
+// OrderedMember is a member of OrderedObject.
+type OrderedMember struct {
+	Key   string
+	Value interface{}
+}
+
+// Get returns the value of the member with key.
+func (o OrderedObject) Get(key string) (interface{}, bool) {
+	if i := o.index(key); i >= 0 {
+		return o[i].Value, true
+	}
+	return nil, false
+}
+
+// Set replaces the value of the member with key keeping its position, or appends the member if it doesn't exist.
+func (o *OrderedObject) Set(key string, value interface{}) {
+	if i := o.index(key); i >= 0 {
+		(*o)[i].Value = value
+		return
+	}
+	*o = append(*o, OrderedMember{Key: key, Value: value})
+}
+
+// Delete removes the member with key, and reports whether it existed.
+func (o *OrderedObject) Delete(key string) bool {
+	i := o.index(key)
+	if i < 0 {
+		return false
+	}
+	*o = append((*o)[:i], (*o)[i+1:]...)
+	return true
+}
+
+// Keys returns the keys of the members in order.
+func (o OrderedObject) Keys() []string {
+	keys := make([]string, 0, len(o))
+	for _, m := range o {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
+
+func (o OrderedObject) index(key string) int {
+	for i, m := range o {
+		if m.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// dedupe resolves the duplicated keys of the decoded object once all members are appended.
+// The last value wins and keeps the position of the first member in the same way as Set.
+func (o *OrderedObject) dedupe() {
+	if len(*o) < 2 {
+		return
+	}
+	var index map[string]int
+	if len(*o) > smallObjectSize {
+		index = make(map[string]int, len(*o))
+	}
+	members := (*o)[:0]
+	for _, m := range *o {
+		i := -1
+		if index != nil {
+			if idx, exists := index[m.Key]; exists {
+				i = idx
+			}
+		} else {
+			i = members.index(m.Key)
+		}
+		if i >= 0 {
+			members[i].Value = m.Value
+			continue
+		}
+		if index != nil {
+			index[m.Key] = len(members)
+		}
+		members = append(members, m)
+	}
+	*o = members
+}
+
+// MarshalJSON encodes the members in order.
+// HTML characters are left unescaped here and are escaped by the encoder calling it as configured.
+func (o OrderedObject) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = encoder.NormalizeUTF8Option
+	b, err := appendOrderedJSON(ctx, nil, o)
+	encoder.ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// appendOrderedJSON encodes the values made by the interface decoder directly,
+// and the other values through Value.
+func appendOrderedJSON(ctx *encoder.RuntimeContext, b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case string:
+		return encoder.AppendString(ctx, b, v), nil
+	case json.Number:
+		return encoder.AppendNumber(ctx, b, v)
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case uint64:
+		return strconv.AppendUint(b, v, 10), nil
+	case float64:
+		if !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return encoder.AppendFloat64(ctx, b, v), nil
+		}
+	case []interface{}:
+		b = append(b, '[')
+		for i, elem := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendOrderedJSON(ctx, b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	case OrderedObject:
+		if v == nil {
+			return append(b, "null"...), nil
+		}
+		b = append(b, '{')
+		for i, m := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = encoder.AppendString(ctx, b, m.Key)
+			b = append(b, ':')
+			var err error
+			if b, err = appendOrderedJSON(ctx, b, m.Value); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, '}'), nil
+	}
+	value, err := ValueOf(v)
+	if err != nil {
+		return nil, err
+	}
+	return value.appendJSON(ctx, b), nil
+}
+
+// UnmarshalJSON decodes the JSON object keeping the order of the members.
+// The nested objects are decoded into OrderedObject too.
+// It's used when the object is decoded by the other packages, and the decoder of this package decodes it directly.
+func (o *OrderedObject) UnmarshalJSON(b []byte) error {
+	src := make([]byte, len(b)+1) // append nul byte to the end
+	copy(src, b)
+
+	ctx := TakeRuntimeContext()
+	ctx.Buf = src
+	ctx.Option.Flags = 0
+	_, err := orderedObjectDec.Decode(ctx, 0, 0, unsafe.Pointer(o))
+	ReleaseRuntimeContext(ctx)
+	return err
+}
+
+var (
+	orderedObjectType = runtime.Type2RType(reflect.TypeOf(OrderedObject{}))
+	orderedObjectDec  = newOrderedObjectDecoder("", "")
+)
+
+// orderedObjectDecoder decodes JSON object into OrderedObject with the interface decoder in the ordered mode,
+// so that the nested objects are decoded into OrderedObject too and the other runtime options are kept.
+type orderedObjectDecoder struct {
+	ifaceDecoder *interfaceDecoder
+	structName   string
+	fieldName    string
+}
+
+func newOrderedObjectDecoder(structName, fieldName string) *orderedObjectDecoder {
+	return &orderedObjectDecoder{
+		ifaceDecoder: newEmptyInterfaceDecoder(structName, fieldName),
+		structName:   structName,
+		fieldName:    fieldName,
+	}
+}
+
+func (d *orderedObjectDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	switch s.skipWhiteSpace() {
+	case 'n':
+		if err := nullBytes(s); err != nil {
+			return err
+		}
+		*(*OrderedObject)(p) = nil
+		return nil
+	case '{':
+	default:
+		return errors.ErrExpected("{ character for ordered object", s.totalOffset())
+	}
+	flags := s.Option.Flags
+	s.Option.Flags |= OrderedObjectOption
+	var v interface{}
+	err := d.ifaceDecoder.decodeStreamOrderedObject(s, depth, unsafe.Pointer(&v))
+	s.Option.Flags = flags
+	if err != nil {
+		return err
+	}
+	*(*OrderedObject)(p) = v.(OrderedObject)
+	return nil
+}
+
+func (d *orderedObjectDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	cursor = skipWhiteSpace(buf, cursor)
+	switch buf[cursor] {
+	case 'n':
+		if err := validateNull(buf, cursor); err != nil {
+			return 0, err
+		}
+		*(*OrderedObject)(p) = nil
+		return cursor + 4, nil
+	case '{':
+	default:
+		return 0, errors.ErrExpected("{ character for ordered object", cursor)
+	}
+	flags := ctx.Option.Flags
+	ctx.Option.Flags |= OrderedObjectOption
+	var v interface{}
+	cursor, err := d.ifaceDecoder.decodeOrderedObject(ctx, cursor, depth, unsafe.Pointer(&v))
+	ctx.Option.Flags = flags
+	if err != nil {
+		return 0, err
+	}
+	*(*OrderedObject)(p) = v.(OrderedObject)
+	return cursor, nil
+}
+
+func (d *orderedObjectDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	return nil, 0, fmt.Errorf("json: ordered object decoder does not support decode path")
+}
+
+func (d *interfaceDecoder) decodeStreamOrderedObject(s *Stream, depth int64, p unsafe.Pointer) error {
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return errors.ErrExceededMaxDepth(s.char(), s.cursor)
+	}
+	s.cursor++
+	v := OrderedObject{}
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	if c == '}' {
+		s.cursor++
+		*(*interface{})(p) = v
+		return nil
+	}
+	relaxed := isRelaxed(s.Option)
+	for {
+		if err := s.checkContext(); err != nil {
+			return err
+		}
+		var m OrderedMember
+		if relaxed && isRelaxedKeyStart(s.char()) {
+			if err := d.mapDecoder.decodeRelaxedKeyStream(s, depth, unsafe.Pointer(&m.Key)); err != nil {
+				return err
+			}
+		} else if err := d.mapDecoder.keyDecoder.DecodeStream(s, depth, unsafe.Pointer(&m.Key)); err != nil {
+			return err
+		}
+		if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
+		if !s.equalChar(':') {
+			return errors.ErrExpected("colon after object key", s.totalOffset())
+		}
+		s.cursor++
+		if relaxed {
+			if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+				return err
+			}
+		}
+		if err := d.mapDecoder.valueDecoder.DecodeStream(s, depth, unsafe.Pointer(&m.Value)); err != nil {
+			return err
+		}
+		v = append(v, m)
+		if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
+		if s.equalChar('}') {
+			s.cursor++
+			v.dedupe()
+			*(*interface{})(p) = v
+			return nil
+		}
+		if !s.equalChar(',') {
+			return errors.ErrExpected("comma after object value", s.totalOffset())
+		}
+		s.cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == '}' {
+				s.cursor++
+				v.dedupe()
+				*(*interface{})(p) = v
+				return nil
+			}
+		}
+	}
+}
+
+func (d *interfaceDecoder) decodeOrderedObject(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+	}
+	cursor++
+	v := OrderedObject{}
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if buf[cursor] == '}' {
+		**(**interface{})(unsafe.Pointer(&p)) = v
+		return cursor + 1, nil
+	}
+	relaxed := isRelaxed(ctx.Option)
+	for {
+		var m OrderedMember
+		var keyCursor int64
+		if relaxed && isRelaxedKeyStart(buf[cursor]) {
+			keyCursor, err = d.mapDecoder.decodeRelaxedKey(ctx, cursor, depth, unsafe.Pointer(&m.Key))
+		} else {
+			keyCursor, err = d.mapDecoder.keyDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(&m.Key))
+		}
+		if err != nil {
+			return 0, err
+		}
+		cursor, err = skipWhiteSpaceWithOption(ctx, keyCursor)
+		if err != nil {
+			return 0, err
+		}
+		if buf[cursor] != ':' {
+			return 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		cursor++
+		if relaxed {
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+		}
+		valueCursor, err := d.mapDecoder.valueDecoder.Decode(ctx, cursor, depth, unsafe.Pointer(&m.Value))
+		if err != nil {
+			return 0, err
+		}
+		v = append(v, m)
+		cursor, err = skipWhiteSpaceWithOption(ctx, valueCursor)
+		if err != nil {
+			return 0, err
+		}
+		if buf[cursor] == '}' {
+			v.dedupe()
+			**(**interface{})(unsafe.Pointer(&p)) = v
+			return cursor + 1, nil
+		}
+		if buf[cursor] != ',' {
+			return 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+			if buf[cursor] == '}' {
+				v.dedupe()
+				**(**interface{})(unsafe.Pointer(&p)) = v
+				return cursor + 1, nil
+			}
+		}
+	}
+}
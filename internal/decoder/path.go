@@ -2,6 +2,7 @@ package decoder
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 
@@ -317,6 +318,8 @@ type Path struct {
 	RootSelectorOnly        bool
 	SingleQuotePathSelector bool
 	DoubleQuotePathSelector bool
+	// query is set instead of node when the path is built in the RFC 9535 conformance mode.
+	query *rfcQuery
 }
 
 func (p *Path) Field(sel string) (PathNode, bool, error) {
@@ -333,18 +336,86 @@ func (p *Path) Get(src, dst reflect.Value) error {
 	return p.node.Get(src, dst)
 }
 
+// Singular reports whether the path can match at most one value.
+func (p *Path) Singular() bool {
+	if p.query != nil {
+		return p.query.singular()
+	}
+	for node := p.node; node != nil; {
+		switch n := node.(type) {
+		case *PathSelectorNode:
+			node = n.child
+		case *PathIndexNode:
+			node = n.child
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// recursiveTarget reports whether the current node is the implicit selector of a trailing recursive descent.
+// Scalar values reached through such a node are matches of the whole path.
+func (p *Path) recursiveTarget() bool {
+	return isRecursiveTarget(p.node)
+}
+
+func isRecursiveTarget(node PathNode) bool {
+	n, ok := node.(*PathSelectorNode)
+	return ok && n.recursive && n.child == nil
+}
+
+func (p *Path) SetValue(dst, value reflect.Value, opt *PathSetOption) error {
+	if p.query != nil {
+		return errors.ErrInvalidPath("SetValue is not supported by RFC 9535 path")
+	}
+	if !dst.IsValid() {
+		return fmt.Errorf("invalid dst type. required non-nil pointer type: nil")
+	}
+	if dst.Type().Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("invalid dst type. required non-nil pointer type: %s", dst.Type())
+	}
+	// the value is set to a copy first, so that dst is left untouched if it fails on the way.
+	trial := reflect.New(dst.Type().Elem())
+	trial.Elem().Set(clonePathValue(dst.Elem(), map[uintptr]reflect.Value{}))
+	if err := p.setValue(trial.Elem(), value, opt); err != nil {
+		return err
+	}
+	return p.setValue(dst.Elem(), value, opt)
+}
+
+func (p *Path) setValue(dst, value reflect.Value, opt *PathSetOption) error {
+	if p.node == nil {
+		return setPathValue(dst, value)
+	}
+	return p.node.Set(dst, value, opt)
+}
+
 func (p *Path) String() string {
+	if p.query != nil {
+		return p.query.src
+	}
 	if p.node == nil {
 		return "$"
 	}
 	return p.node.String()
 }
 
+var (
+	pathMapType   = reflect.TypeOf(map[string]interface{}{})
+	pathSliceType = reflect.TypeOf([]interface{}{})
+)
+
+type PathSetOption struct {
+	GrowSlice bool
+}
+
 type PathNode interface {
 	fmt.Stringer
 	Index(idx int) (PathNode, bool, error)
 	Field(fieldName string) (PathNode, bool, error)
 	Get(src, dst reflect.Value) error
+	Set(dst, value reflect.Value, opt *PathSetOption) error
 	chain(PathNode) PathNode
 	target() bool
 	single() bool
@@ -369,7 +440,8 @@ func (n *BasePathNode) single() bool {
 
 type PathSelectorNode struct {
 	*BasePathNode
-	selector string
+	selector  string
+	recursive bool
 }
 
 func newPathSelectorNode(selector string) *PathSelectorNode {
@@ -435,6 +507,48 @@ func (n *PathSelectorNode) Get(src, dst reflect.Value) error {
 	return fmt.Errorf("failed to get %s value from %s", n.selector, src.Type())
 }
 
+func (n *PathSelectorNode) Set(dst, value reflect.Value, opt *PathSetOption) error {
+	switch dst.Type().Kind() {
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("invalid map key type %s", dst.Type().Key())
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		key := reflect.ValueOf(n.selector).Convert(dst.Type().Key())
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if n.child == nil {
+			if err := setPathValue(elem, value); err != nil {
+				return err
+			}
+		} else {
+			if v := dst.MapIndex(key); v.IsValid() {
+				elem.Set(v)
+			}
+			if err := n.child.Set(elem, value, opt); err != nil {
+				return err
+			}
+		}
+		dst.SetMapIndex(key, elem)
+		return nil
+	case reflect.Struct:
+		field, found := structFieldByKey(dst, n.selector)
+		if !found {
+			break
+		}
+		if n.child != nil {
+			return n.child.Set(field, value, opt)
+		}
+		return setPathValue(field, value)
+	case reflect.Ptr, reflect.Interface:
+		return setPathIndirect(dst, pathMapType, func(v reflect.Value) error {
+			return n.Set(v, value, opt)
+		})
+	}
+	return fmt.Errorf("failed to set %s value to %s", n.selector, dst.Type())
+}
+
 func (n *PathSelectorNode) String() string {
 	s := fmt.Sprintf(".%s", n.selector)
 	if n.child != nil {
@@ -483,6 +597,37 @@ func (n *PathIndexNode) Get(src, dst reflect.Value) error {
 	return fmt.Errorf("failed to get [%d] value from %s", n.selector, src.Type())
 }
 
+func (n *PathIndexNode) Set(dst, value reflect.Value, opt *PathSetOption) error {
+	switch dst.Type().Kind() {
+	case reflect.Slice:
+		if n.selector < 0 {
+			break
+		}
+		if dst.Len() <= n.selector {
+			if !opt.GrowSlice {
+				return fmt.Errorf("failed to set [%d] value to %s of %d length", n.selector, dst.Type(), dst.Len())
+			}
+			grown := reflect.MakeSlice(dst.Type(), n.selector+1, n.selector+1)
+			reflect.Copy(grown, dst)
+			dst.Set(grown)
+		}
+		fallthrough
+	case reflect.Array:
+		if n.selector < 0 || dst.Len() <= n.selector {
+			break
+		}
+		if n.child != nil {
+			return n.child.Set(dst.Index(n.selector), value, opt)
+		}
+		return setPathValue(dst.Index(n.selector), value)
+	case reflect.Ptr, reflect.Interface:
+		return setPathIndirect(dst, pathSliceType, func(v reflect.Value) error {
+			return n.Set(v, value, opt)
+		})
+	}
+	return fmt.Errorf("failed to set [%d] value to %s", n.selector, dst.Type())
+}
+
 func (n *PathIndexNode) String() string {
 	s := fmt.Sprintf("[%d]", n.selector)
 	if n.child != nil {
@@ -539,6 +684,32 @@ func (n *PathIndexAllNode) Get(src, dst reflect.Value) error {
 	return fmt.Errorf("failed to get all value from %s", src.Type())
 }
 
+func (n *PathIndexAllNode) Set(dst, value reflect.Value, opt *PathSetOption) error {
+	switch dst.Type().Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < dst.Len(); i++ {
+			if n.child != nil {
+				if err := n.child.Set(dst.Index(i), value, opt); err != nil {
+					return err
+				}
+			} else {
+				if err := setPathValue(dst.Index(i), value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if dst.IsNil() {
+			return nil
+		}
+		return setPathIndirect(dst, nil, func(v reflect.Value) error {
+			return n.Set(v, value, opt)
+		})
+	}
+	return fmt.Errorf("failed to set all value to %s", dst.Type())
+}
+
 func (n *PathIndexAllNode) String() string {
 	s := "[*]"
 	if n.child != nil {
@@ -554,6 +725,7 @@ type PathRecursiveNode struct {
 
 func newPathRecursiveNode(selector string) *PathRecursiveNode {
 	node := newPathSelectorNode(selector)
+	node.recursive = true
 	return &PathRecursiveNode{
 		BasePathNode: &BasePathNode{
 			child: node,
@@ -661,6 +833,65 @@ func (n *PathRecursiveNode) Get(src, dst reflect.Value) error {
 	return fmt.Errorf("failed to get %s value from %s", n.selector, src.Type())
 }
 
+func (n *PathRecursiveNode) Set(dst, value reflect.Value, opt *PathSetOption) error {
+	if n.child == nil {
+		return fmt.Errorf("failed to set by recursive path ..%s", n.selector)
+	}
+	setMember := func(elem reflect.Value, found bool) error {
+		if !found {
+			return n.Set(elem, value, opt)
+		}
+		if child, ok := n.child.(*PathSelectorNode); ok && child.recursive {
+			return setPathValue(elem, value)
+		}
+		return n.child.Set(elem, value, opt)
+	}
+	switch dst.Type().Kind() {
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		iter := dst.MapRange()
+		for iter.Next() {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if err := setMember(elem, iter.Key().String() == n.selector); err != nil {
+				return err
+			}
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+		return nil
+	case reflect.Struct:
+		typ := dst.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if runtime.IsIgnoredStructField(field) || !dst.Field(i).CanSet() {
+				continue
+			}
+			tag := runtime.StructTagFromField(field)
+			if err := setMember(dst.Field(i), tag.Key == n.selector); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < dst.Len(); i++ {
+			if err := n.Set(dst.Index(i), value, opt); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if dst.IsNil() {
+			return nil
+		}
+		return setPathIndirect(dst, nil, func(v reflect.Value) error {
+			return n.Set(v, value, opt)
+		})
+	}
+	return nil
+}
+
 func (n *PathRecursiveNode) String() string {
 	s := fmt.Sprintf("..%s", n.selector)
 	if n.child != nil {
@@ -668,3 +899,197 @@ func (n *PathRecursiveNode) String() string {
 	}
 	return s
 }
+
+// setPathIndirect calls fn with the value referenced by the pointer or interface dst.
+// A nil pointer is allocated and a nil interface is filled with a value of typ
+// so that the path can be created on the way.
+// Values held by interfaces aren't addressable, so a copy is modified and stored back.
+func setPathIndirect(dst reflect.Value, typ reflect.Type, fn func(reflect.Value) error) error {
+	if dst.Type().Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			if !dst.CanSet() {
+				return fmt.Errorf("failed to allocate nil %s", dst.Type())
+			}
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return fn(dst.Elem())
+	}
+	var elem reflect.Value
+	if dst.IsNil() {
+		if typ == nil || !typ.AssignableTo(dst.Type()) {
+			return fmt.Errorf("failed to set value to nil %s", dst.Type())
+		}
+		elem = reflect.New(typ).Elem()
+	} else {
+		v := dst.Elem()
+		elem = reflect.New(v.Type()).Elem()
+		elem.Set(v)
+	}
+	if err := fn(elem); err != nil {
+		return err
+	}
+	dst.Set(elem)
+	return nil
+}
+
+func setPathValue(dst, value reflect.Value) error {
+	if !dst.CanSet() {
+		return fmt.Errorf("failed to set value to unaddressable %s", dst.Type())
+	}
+	if !value.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if value.Type().AssignableTo(dst.Type()) {
+		dst.Set(value)
+		return nil
+	}
+	if err := checkPathNumber(dst.Type(), value); err != nil {
+		return err
+	}
+	casted, err := castValue(dst.Type(), value)
+	if err != nil {
+		return err
+	}
+	switch {
+	case casted.Type().AssignableTo(dst.Type()):
+		dst.Set(casted)
+	case casted.Type().ConvertibleTo(dst.Type()):
+		dst.Set(casted.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("failed to set %s value to %s", value.Type(), dst.Type())
+	}
+	return nil
+}
+
+// checkPathNumber returns UnmarshalTypeError if the number can't be set to the integer type typ
+// without truncating the fraction or overflowing.
+func checkPathNumber(typ reflect.Type, value reflect.Value) error {
+	zero := reflect.Zero(typ)
+	var truncated bool
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch value.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := value.Float()
+			truncated = f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || zero.OverflowInt(int64(f))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			truncated = zero.OverflowInt(value.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			truncated = value.Uint() > math.MaxInt64 || zero.OverflowInt(int64(value.Uint()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch value.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := value.Float()
+			truncated = f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || zero.OverflowUint(uint64(f))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			truncated = value.Int() < 0 || zero.OverflowUint(uint64(value.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			truncated = zero.OverflowUint(value.Uint())
+		}
+	}
+	if truncated {
+		return &errors.UnmarshalTypeError{
+			Value: fmt.Sprintf("number %v", value.Interface()),
+			Type:  typ,
+		}
+	}
+	return nil
+}
+
+// clonePathValue returns the deep copy of v, so that SetValue can try to set the value without mutating v.
+// The unexported struct fields are shallow copied.
+func clonePathValue(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if cloned, exists := seen[v.Pointer()]; exists {
+			return cloned
+		}
+		cloned := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = cloned
+		cloned.Elem().Set(clonePathValue(v.Elem(), seen))
+		return cloned
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(clonePathValue(v.Elem(), seen))
+		return cloned
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cloned.SetMapIndex(iter.Key(), clonePathValue(iter.Value(), seen))
+		}
+		return cloned
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cloned := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(clonePathValue(v.Index(i), seen))
+		}
+		return cloned
+	case reflect.Array:
+		cloned := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cloned.Index(i).Set(clonePathValue(v.Index(i), seen))
+		}
+		return cloned
+	case reflect.Struct:
+		cloned := reflect.New(v.Type()).Elem()
+		cloned.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := cloned.Field(i); field.CanSet() {
+				field.Set(clonePathValue(v.Field(i), seen))
+			}
+		}
+		return cloned
+	}
+	return v
+}
+
+func structFieldByKey(v reflect.Value, key string) (reflect.Value, bool) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if runtime.IsIgnoredStructField(field) {
+			continue
+		}
+		tag := runtime.StructTagFromField(field)
+		if field.Anonymous && !tag.IsTaggedKey {
+			embedded := v.Field(i)
+			if embedded.Type().Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					if !embedded.CanSet() {
+						continue
+					}
+					if _, found := structFieldByKey(reflect.New(embedded.Type().Elem()).Elem(), key); !found {
+						continue
+					}
+					embedded.Set(reflect.New(embedded.Type().Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Type().Kind() == reflect.Struct {
+				if f, found := structFieldByKey(embedded, key); found {
+					return f, true
+				}
+				continue
+			}
+		}
+		if tag.Key == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
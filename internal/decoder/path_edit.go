@@ -0,0 +1,492 @@
+package decoder
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// PathMatch represents a value matched by JSON Path and its location in the source.
+type PathMatch struct {
+	// Value is the raw bytes of the matched value.
+	Value []byte
+	// NormalizedPath is the normalized path of the value (e.g. $['items'][3]['id']).
+	NormalizedPath string
+	// Start and End are the byte offsets of the value in the source, End is exclusive.
+	Start int64
+	End   int64
+}
+
+var (
+	pathMatchDecoder = NewPathDecoder()
+	pathKeyDecoder   = newStringDecoder("", "")
+)
+
+// Matches returns all values matched by the JSON Path in data in document order.
+func (p *Path) Matches(data []byte) ([]PathMatch, error) {
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	return p.matches(src)
+}
+
+// matches expects src to be terminated by a nul byte.
+func (p *Path) matches(src []byte) ([]PathMatch, error) {
+	if p.query != nil {
+		return p.query.matches(src)
+	}
+	if p.node == nil {
+		start := skipWhiteSpace(src, 0)
+		end, err := skipValue(src, start, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEndOfValue(src, end); err != nil {
+			return nil, err
+		}
+		return []PathMatch{{Value: src[start:end], NormalizedPath: "$", Start: start, End: end}}, nil
+	}
+	// the path decoder unescapes the strings in place, so it runs on a copy to keep src as it is.
+	buf := make([]byte, len(src))
+	copy(buf, src)
+	ctx := TakeRuntimeContext()
+	ctx.Buf = buf
+	ctx.Option.Flags = PathOption | PathMatchOption
+	ctx.Option.Path = p
+	ctx.Option.matches = nil
+	ctx.Option.location = ctx.Option.location[:0]
+	_, cursor, err := pathMatchDecoder.DecodePath(ctx, 0, 0)
+	matches := ctx.Option.matches
+	ctx.Option.matches = nil
+	ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEndOfValue(src, cursor); err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		matches[i].Start = skipWhiteSpace(src, matches[i].Start)
+		matches[i].Value = src[matches[i].Start:matches[i].End]
+	}
+	return matches, nil
+}
+
+// Set replaces the values matched by the JSON Path in data with value, which must be encoded JSON.
+// If the last selector of the path is a field name or an index that does not exist yet, it's inserted,
+// creating missing parent objects and arrays as needed.
+// The rest of data, including whitespace and key order, is kept untouched.
+func (p *Path) Set(data, value []byte) ([]byte, error) {
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	src, err := p.set(src, value)
+	if err != nil {
+		return nil, err
+	}
+	return src[:len(src)-1], nil
+}
+
+func (p *Path) set(src, value []byte) ([]byte, error) {
+	parent, last := p.splitLast()
+	if parent == nil {
+		matches, err := p.editMatches(src)
+		if err != nil {
+			return nil, err
+		}
+		for _, start := range matchStarts(matches) {
+			end, err := skipValue(src, start, 0)
+			if err != nil {
+				return nil, err
+			}
+			src = splice(src, start, end, value)
+		}
+		return src, nil
+	}
+	matches, err := parent.matches(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		container := []byte("{}")
+		if _, ok := last.(*PathIndexNode); ok {
+			container = []byte("[]")
+		}
+		created, err := parent.set(src, container)
+		if err != nil {
+			return nil, err
+		}
+		src = created
+		matches, err = parent.matches(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, start := range matchStarts(matches) {
+		switch node := last.(type) {
+		case *PathSelectorNode:
+			src, err = setObjectMember(src, start, node.selector, value)
+		case *PathIndexNode:
+			src, err = setArrayElement(src, start, node.selector, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return src, nil
+}
+
+// Delete removes the values matched by the JSON Path from data.
+// Object members are removed together with their keys, and array elements are removed from their arrays.
+// The rest of data, including whitespace and key order, is kept untouched.
+func (p *Path) Delete(data []byte) ([]byte, error) {
+	if p.RootSelectorOnly {
+		return nil, errors.ErrInvalidPath("the root value cannot be deleted")
+	}
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	matches, err := p.editMatches(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, start := range matchStarts(matches) {
+		end, err := skipValue(src, start, 0)
+		if err != nil {
+			return nil, err
+		}
+		memberStart, memberEnd := memberRange(src, start, end)
+		src = splice(src, memberStart, memberEnd, nil)
+	}
+	return src[:len(src)-1], nil
+}
+
+// editMatches returns the values edited by Set and Delete.
+// The recursive descent selector of the path reaches every descendant here, including the ones nested in a match,
+// so the path is evaluated as RFC 9535 query if it has the selector.
+func (p *Path) editMatches(src []byte) ([]PathMatch, error) {
+	if query := p.descendantQuery(); query != nil {
+		return query.matches(src)
+	}
+	return p.matches(src)
+}
+
+// descendantQuery converts the path to RFC 9535 query if it has the recursive descent selector, otherwise returns nil.
+func (p *Path) descendantQuery() *rfcQuery {
+	query := &rfcQuery{src: p.String()}
+	var descendant bool
+	for node := p.node; node != nil; {
+		var seg *rfcSegment
+		switch n := node.(type) {
+		case *PathRecursiveNode:
+			descendant = true
+			seg = &rfcSegment{descendant: true, selectors: []*rfcSelector{{typ: rfcNameSelector, name: n.selector}}}
+			node = n.child
+		case *PathSelectorNode:
+			if !n.recursive {
+				// the selector of the trailing recursive descent is already added with PathRecursiveNode.
+				seg = &rfcSegment{selectors: []*rfcSelector{{typ: rfcNameSelector, name: n.selector}}}
+			}
+			node = n.child
+		case *PathIndexNode:
+			seg = &rfcSegment{selectors: []*rfcSelector{{typ: rfcIndexSelector, index: int64(n.selector)}}}
+			node = n.child
+		case *PathIndexAllNode:
+			seg = &rfcSegment{selectors: []*rfcSelector{{typ: rfcWildcardSelector}}}
+			node = n.child
+		default:
+			return nil
+		}
+		if seg != nil {
+			query.segments = append(query.segments, seg)
+		}
+	}
+	if !descendant {
+		return nil
+	}
+	return query
+}
+
+// splitLast splits the path into the parent path and the last node
+// when the path consists only of field name, index and index all selectors.
+// Otherwise, it returns nil for the parent path.
+func (p *Path) splitLast() (*Path, PathNode) {
+	var nodes []PathNode
+	for node := p.node; node != nil; {
+		nodes = append(nodes, node)
+		switch n := node.(type) {
+		case *PathSelectorNode:
+			node = n.child
+		case *PathIndexNode:
+			node = n.child
+		case *PathIndexAllNode:
+			node = n.child
+		default:
+			return nil, nil
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	last := nodes[len(nodes)-1]
+	if _, ok := last.(*PathIndexAllNode); ok {
+		return nil, nil
+	}
+	builder := new(PathBuilder)
+	for _, node := range nodes[:len(nodes)-1] {
+		switch n := node.(type) {
+		case *PathSelectorNode:
+			builder.addSelectorNode(n.selector)
+		case *PathIndexNode:
+			builder.addIndexNode(n.selector)
+		case *PathIndexAllNode:
+			builder.addIndexAllNode()
+		}
+	}
+	return &Path{node: builder.root, RootSelectorOnly: builder.root == nil}, last
+}
+
+// matchStarts returns the unique start offsets of matches in descending order,
+// so that splicing at one offset never invalidates the following ones.
+func matchStarts(matches []PathMatch) []int64 {
+	starts := make([]int64, 0, len(matches))
+	for _, m := range matches {
+		starts = append(starts, m.Start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] > starts[j] })
+	uniq := starts[:0]
+	for i, start := range starts {
+		if i > 0 && starts[i-1] == start {
+			continue
+		}
+		uniq = append(uniq, start)
+	}
+	return uniq
+}
+
+func splice(src []byte, start, end int64, value []byte) []byte {
+	ret := make([]byte, 0, int64(len(src))-(end-start)+int64(len(value)))
+	ret = append(ret, src[:start]...)
+	ret = append(ret, value...)
+	return append(ret, src[end:]...)
+}
+
+func validateEndOfValue(src []byte, cursor int64) error {
+	cursor = skipWhiteSpace(src, cursor)
+	if src[cursor] != nul {
+		return errors.ErrSyntax("invalid character after top-level value", cursor+1)
+	}
+	return nil
+}
+
+func skipWhiteSpaceBackward(src []byte, cursor int64) int64 {
+	for cursor >= 0 && isWhiteSpace[src[cursor]] {
+		cursor--
+	}
+	return cursor
+}
+
+// memberRange returns the range to remove so that the object member or array element
+// whose value is located at [start, end) disappears together with its separator.
+func memberRange(src []byte, start, end int64) (int64, int64) {
+	memberStart := start
+	prev := skipWhiteSpaceBackward(src, start-1)
+	if src[prev] == ':' {
+		keyEnd := skipWhiteSpaceBackward(src, prev-1)
+		keyStart := keyEnd - 1
+		for src[keyStart] != '"' || isEscaped(src, keyStart) {
+			keyStart--
+		}
+		memberStart = keyStart
+		prev = skipWhiteSpaceBackward(src, keyStart-1)
+	}
+	if src[prev] == ',' {
+		return prev, end
+	}
+	next := skipWhiteSpace(src, end)
+	if src[next] == ',' {
+		return memberStart, skipWhiteSpace(src, next+1)
+	}
+	// the only member of the container
+	return prev + 1, next
+}
+
+func isEscaped(src []byte, cursor int64) bool {
+	escaped := false
+	for cursor--; cursor >= 0 && src[cursor] == '\\'; cursor-- {
+		escaped = !escaped
+	}
+	return escaped
+}
+
+func setObjectMember(src []byte, start int64, key string, value []byte) ([]byte, error) {
+	if src[start] != '{' {
+		return nil, errors.ErrInvalidPath("cannot set field %q on a non-object value", key)
+	}
+	cursor := skipWhiteSpace(src, start+1)
+	if src[cursor] == '}' {
+		member := append(append(appendQuotedKey(nil, key), ':'), value...)
+		return splice(src, cursor, cursor, member), nil
+	}
+	firstKeyStart := cursor
+	var (
+		indent       = src[start+1 : cursor]
+		separated    bool
+		keyToColon   []byte
+		colonToValue []byte
+		lastEnd      int64
+	)
+	for {
+		k, keyEnd, err := decodePathSetString(src, cursor)
+		if err != nil {
+			return nil, err
+		}
+		colon := skipWhiteSpace(src, keyEnd)
+		if src[colon] != ':' {
+			return nil, errors.ErrExpected("colon after object key", colon)
+		}
+		valueStart := skipWhiteSpace(src, colon+1)
+		if cursor == firstKeyStart {
+			keyToColon = src[keyEnd:colon]
+			colonToValue = src[colon+1 : valueStart]
+		}
+		valueEnd, err := skipValue(src, valueStart, 0)
+		if err != nil {
+			return nil, err
+		}
+		if string(k) == key {
+			return splice(src, valueStart, valueEnd, value), nil
+		}
+		lastEnd = valueEnd
+		cursor = skipWhiteSpace(src, valueEnd)
+		if src[cursor] == '}' {
+			break
+		}
+		if src[cursor] != ',' {
+			return nil, errors.ErrExpected("comma after object value", cursor)
+		}
+		next := skipWhiteSpace(src, cursor+1)
+		if !separated {
+			indent = src[cursor+1 : next]
+			separated = true
+		}
+		cursor = next
+	}
+	// reuse the formatting of the existing members for the new one.
+	member := append([]byte{','}, indent...)
+	member = appendQuotedKey(member, key)
+	member = append(member, keyToColon...)
+	member = append(member, ':')
+	member = append(member, colonToValue...)
+	member = append(member, value...)
+	return splice(src, lastEnd, lastEnd, member), nil
+}
+
+func setArrayElement(src []byte, start int64, idx int, value []byte) ([]byte, error) {
+	if src[start] != '[' {
+		return nil, errors.ErrInvalidPath("cannot set index %d on a non-array value", idx)
+	}
+	if idx < 0 {
+		return nil, errors.ErrInvalidPath("cannot set negative index %d", idx)
+	}
+	cursor := skipWhiteSpace(src, start+1)
+	if src[cursor] == ']' {
+		elems := []byte{}
+		for i := 0; i < idx; i++ {
+			elems = append(elems, "null,"...)
+		}
+		return splice(src, cursor, cursor, append(elems, value...)), nil
+	}
+	indent := src[start+1 : cursor]
+	var lastEnd int64
+	for i := 0; ; i++ {
+		end, err := skipValue(src, cursor, 0)
+		if err != nil {
+			return nil, err
+		}
+		if i == idx {
+			return splice(src, cursor, end, value), nil
+		}
+		lastEnd = end
+		cursor = skipWhiteSpace(src, end)
+		if src[cursor] == ']' {
+			// reuse the formatting of the existing elements for the appended ones.
+			sep := append([]byte{','}, indent...)
+			elems := []byte{}
+			for j := i + 1; j < idx; j++ {
+				elems = append(append(elems, sep...), nullbytes...)
+			}
+			elems = append(append(elems, sep...), value...)
+			return splice(src, lastEnd, lastEnd, elems), nil
+		}
+		if src[cursor] != ',' {
+			return nil, errors.ErrInvalidCharacter(src[cursor], "slice", cursor)
+		}
+		next := skipWhiteSpace(src, cursor+1)
+		if i == 0 {
+			indent = src[cursor+1 : next]
+		}
+		cursor = next
+	}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// normalizedPath builds the normalized path defined by RFC 9535 for the location.
+func normalizedPath(location []pathLocation) string {
+	b := []byte{'$'}
+	for _, loc := range location {
+		if loc.isIndex {
+			b = append(b, '[')
+			b = strconv.AppendInt(b, int64(loc.index), 10)
+			b = append(b, ']')
+			continue
+		}
+		b = append(b, '[', '\'')
+		for i := 0; i < len(loc.field); i++ {
+			switch c := loc.field[i]; c {
+			case '\'', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				if c < 0x20 {
+					b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+					continue
+				}
+				b = append(b, c)
+			}
+		}
+		b = append(b, '\'', ']')
+	}
+	return string(b)
+}
+
+func appendQuotedKey(b []byte, key string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; c {
+		case '"', '\\':
+			b = append(b, '\\', c)
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		default:
+			if c < 0x20 {
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+				continue
+			}
+			b = append(b, c)
+		}
+	}
+	return append(b, '"')
+}
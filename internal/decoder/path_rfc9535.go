@@ -0,0 +1,876 @@
+package decoder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// PathBuildOption is the option for building JSON Path.
+type PathBuildOption struct {
+	// RFC9535 builds JSON Path strictly following RFC 9535.
+	RFC9535 bool
+}
+
+// BuildWithOption builds JSON Path with opt.
+func (s PathString) BuildWithOption(opt *PathBuildOption) (*Path, error) {
+	if !opt.RFC9535 {
+		return s.Build()
+	}
+	query, err := parseRFC9535Query(string(s))
+	if err != nil {
+		return nil, err
+	}
+	return &Path{
+		query:            query,
+		RootSelectorOnly: len(query.segments) == 0,
+	}, nil
+}
+
+// RFC9535 whether the path was built in the RFC 9535 conformance mode.
+func (p *Path) RFC9535() bool {
+	return p.query != nil
+}
+
+// rfcQuery is a JSON Path query of RFC 9535.
+type rfcQuery struct {
+	src      string
+	relative bool
+	segments []*rfcSegment
+}
+
+type rfcSegment struct {
+	descendant bool
+	selectors  []*rfcSelector
+}
+
+type rfcSelectorType int
+
+const (
+	rfcNameSelector rfcSelectorType = iota
+	rfcWildcardSelector
+	rfcIndexSelector
+	rfcSliceSelector
+	rfcFilterSelector
+)
+
+type rfcSelector struct {
+	typ    rfcSelectorType
+	name   string
+	index  int64
+	start  *int64
+	end    *int64
+	step   *int64
+	filter rfcLogicalExpr
+}
+
+// singular reports whether the query always produces at most one node.
+func (q *rfcQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].typ {
+		case rfcNameSelector, rfcIndexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// rfcType is the type of the function extension arguments and results.
+type rfcType int
+
+const (
+	rfcValueType rfcType = iota
+	rfcLogicalType
+	rfcNodesType
+)
+
+// rfcLogicalExpr is an expression producing LogicalType.
+type rfcLogicalExpr interface {
+	evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool
+}
+
+// rfcComparable is an expression producing ValueType.
+type rfcComparable interface {
+	evalValue(ctx *rfcEvalContext, current *rfcPathNode) *rfcValue
+}
+
+type rfcOrExpr struct {
+	exprs []rfcLogicalExpr
+}
+
+type rfcAndExpr struct {
+	exprs []rfcLogicalExpr
+}
+
+type rfcNotExpr struct {
+	expr rfcLogicalExpr
+}
+
+type rfcComparisonExpr struct {
+	op    string
+	left  rfcComparable
+	right rfcComparable
+}
+
+// rfcExistenceExpr tests whether the query selects at least one node.
+type rfcExistenceExpr struct {
+	query *rfcQuery
+}
+
+type rfcLiteral struct {
+	value *rfcValue
+}
+
+// rfcPatternLiteral is a string literal used as the pattern of match or search, which is compiled at parse time.
+// re is nil if the pattern isn't valid.
+type rfcPatternLiteral struct {
+	*rfcLiteral
+	re *regexp.Regexp
+}
+
+// rfcSingularQuery is a singular query used as a comparable, which produces the value of the selected node.
+type rfcSingularQuery struct {
+	query *rfcQuery
+}
+
+type rfcFunctionExpr struct {
+	function *rfcFunction
+	args     []interface{}
+}
+
+type rfcParser struct {
+	src    string
+	cursor int
+}
+
+func parseRFC9535Query(src string) (*rfcQuery, error) {
+	if src == "" {
+		return nil, errors.ErrEmptyPath()
+	}
+	p := &rfcParser{src: src}
+	if p.char() != '$' {
+		return nil, p.error("JSON Path must start with a $ character")
+	}
+	p.cursor++
+	query, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.error("unexpected character %q", p.char())
+	}
+	query.src = src
+	return query, nil
+}
+
+func (p *rfcParser) error(msg string, args ...interface{}) error {
+	return errors.ErrInvalidPath("%q at %d: %s", p.src, p.cursor, fmt.Sprintf(msg, args...))
+}
+
+func (p *rfcParser) eof() bool {
+	return p.cursor >= len(p.src)
+}
+
+func (p *rfcParser) char() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.cursor]
+}
+
+func (p *rfcParser) peek(s string) bool {
+	return len(p.src)-p.cursor >= len(s) && p.src[p.cursor:p.cursor+len(s)] == s
+}
+
+func (p *rfcParser) skipBlank() {
+	for !p.eof() {
+		switch p.char() {
+		case ' ', '\t', '\n', '\r':
+			p.cursor++
+		default:
+			return
+		}
+	}
+}
+
+func (p *rfcParser) parseSegments() (*rfcQuery, error) {
+	query := &rfcQuery{}
+	for {
+		// blank spaces are allowed before a segment, but not at the end of the query.
+		start := p.cursor
+		p.skipBlank()
+		if p.char() != '.' && p.char() != '[' {
+			p.cursor = start
+			return query, nil
+		}
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		query.segments = append(query.segments, seg)
+	}
+}
+
+func (p *rfcParser) parseSegment() (*rfcSegment, error) {
+	if p.char() == '[' {
+		selectors, err := p.parseBracketedSelection()
+		if err != nil {
+			return nil, err
+		}
+		return &rfcSegment{selectors: selectors}, nil
+	}
+	// dot
+	p.cursor++
+	descendant := false
+	if p.char() == '.' {
+		descendant = true
+		p.cursor++
+		if p.char() == '[' {
+			selectors, err := p.parseBracketedSelection()
+			if err != nil {
+				return nil, err
+			}
+			return &rfcSegment{descendant: true, selectors: selectors}, nil
+		}
+	}
+	if p.char() == '*' {
+		p.cursor++
+		return &rfcSegment{descendant: descendant, selectors: []*rfcSelector{{typ: rfcWildcardSelector}}}, nil
+	}
+	name, err := p.parseMemberNameShorthand()
+	if err != nil {
+		return nil, err
+	}
+	return &rfcSegment{descendant: descendant, selectors: []*rfcSelector{{typ: rfcNameSelector, name: name}}}, nil
+}
+
+func isRFCNameFirst(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' ||
+		r >= 0x80 && r <= 0xD7FF || r >= 0xE000 && r <= 0x10FFFF
+}
+
+func isRFCDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *rfcParser) parseMemberNameShorthand() (string, error) {
+	start := p.cursor
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.cursor:])
+		if r == utf8.RuneError && size <= 1 {
+			return "", p.error("invalid UTF-8 character")
+		}
+		if !isRFCNameFirst(r) && (p.cursor == start || r > utf8.RuneSelf || !isRFCDigit(byte(r))) {
+			break
+		}
+		p.cursor += size
+	}
+	if p.cursor == start {
+		return "", p.error("expected member name")
+	}
+	return p.src[start:p.cursor], nil
+}
+
+func (p *rfcParser) parseBracketedSelection() ([]*rfcSelector, error) {
+	p.cursor++ // '['
+	var selectors []*rfcSelector
+	for {
+		p.skipBlank()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipBlank()
+		switch p.char() {
+		case ']':
+			p.cursor++
+			return selectors, nil
+		case ',':
+			p.cursor++
+		default:
+			return nil, p.error("expected , or ] in bracketed selection")
+		}
+	}
+}
+
+func (p *rfcParser) parseSelector() (*rfcSelector, error) {
+	switch c := p.char(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &rfcSelector{typ: rfcNameSelector, name: name}, nil
+	case c == '*':
+		p.cursor++
+		return &rfcSelector{typ: rfcWildcardSelector}, nil
+	case c == '?':
+		p.cursor++
+		p.skipBlank()
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		return &rfcSelector{typ: rfcFilterSelector, filter: expr}, nil
+	case c == ':' || c == '-' || isRFCDigit(c):
+		return p.parseIndexOrSlice()
+	}
+	return nil, p.error("invalid selector")
+}
+
+func (p *rfcParser) parseIndexOrSlice() (*rfcSelector, error) {
+	var values [3]*int64
+	part := 0
+	for {
+		p.skipBlankIfSlice(part)
+		if p.char() == '-' || isRFCDigit(p.char()) {
+			v, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			values[part] = &v
+			p.skipBlankIfSlice(part)
+		}
+		if p.char() != ':' || part == 2 {
+			break
+		}
+		p.cursor++
+		part++
+	}
+	if part == 0 {
+		if values[0] == nil {
+			return nil, p.error("expected index")
+		}
+		return &rfcSelector{typ: rfcIndexSelector, index: *values[0]}, nil
+	}
+	return &rfcSelector{typ: rfcSliceSelector, start: values[0], end: values[1], step: values[2]}, nil
+}
+
+func (p *rfcParser) skipBlankIfSlice(part int) {
+	if part == 0 {
+		// blank spaces after the index are consumed by the bracketed selection.
+		start := p.cursor
+		p.skipBlank()
+		if p.char() != ':' {
+			p.cursor = start
+		}
+		return
+	}
+	p.skipBlank()
+}
+
+const (
+	rfcMaxSafeInteger = 1<<53 - 1
+)
+
+func (p *rfcParser) parseInt() (int64, error) {
+	start := p.cursor
+	if p.char() == '-' {
+		p.cursor++
+	}
+	if !isRFCDigit(p.char()) {
+		return 0, p.error("expected digit")
+	}
+	if p.char() == '0' {
+		p.cursor++
+		if p.cursor-start > 1 {
+			return 0, p.error("-0 is not allowed")
+		}
+		if isRFCDigit(p.char()) {
+			return 0, p.error("leading zeros are not allowed")
+		}
+		return 0, nil
+	}
+	for isRFCDigit(p.char()) {
+		p.cursor++
+	}
+	v, err := strconv.ParseInt(p.src[start:p.cursor], 10, 64)
+	if err != nil || v > rfcMaxSafeInteger || v < -rfcMaxSafeInteger {
+		return 0, p.error("integer %s is out of range", p.src[start:p.cursor])
+	}
+	return v, nil
+}
+
+func (p *rfcParser) parseStringLiteral() (string, error) {
+	quote := p.char()
+	p.cursor++
+	var b []byte
+	for {
+		if p.eof() {
+			return "", p.error("unterminated string literal")
+		}
+		c := p.char()
+		switch {
+		case c == quote:
+			p.cursor++
+			return string(b), nil
+		case c < 0x20:
+			return "", p.error("control character in string literal")
+		case c == '\\':
+			p.cursor++
+			e := p.char()
+			switch e {
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case '/', '\\':
+				b = append(b, e)
+			case '\'', '"':
+				if e != quote {
+					return "", p.error("invalid escape \\%c", e)
+				}
+				b = append(b, e)
+			case 'u':
+				p.cursor++
+				r, err := p.parseUnicodeEscape()
+				if err != nil {
+					return "", err
+				}
+				b = utf8.AppendRune(b, r)
+				continue
+			default:
+				return "", p.error("invalid escape character")
+			}
+			p.cursor++
+		default:
+			r, size := utf8.DecodeRuneInString(p.src[p.cursor:])
+			if r == utf8.RuneError && size <= 1 {
+				return "", p.error("invalid UTF-8 character")
+			}
+			b = append(b, p.src[p.cursor:p.cursor+size]...)
+			p.cursor += size
+		}
+	}
+}
+
+func (p *rfcParser) parseHex4() (rune, error) {
+	if len(p.src)-p.cursor < 4 {
+		return 0, p.error("invalid unicode escape")
+	}
+	v, err := strconv.ParseUint(p.src[p.cursor:p.cursor+4], 16, 16)
+	if err != nil {
+		return 0, p.error("invalid unicode escape")
+	}
+	p.cursor += 4
+	return rune(v), nil
+}
+
+func (p *rfcParser) parseUnicodeEscape() (rune, error) {
+	r, err := p.parseHex4()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case r >= 0xDC00 && r <= 0xDFFF:
+		return 0, p.error("unpaired low surrogate")
+	case r >= 0xD800 && r <= 0xDBFF:
+		if !p.peek(`\u`) {
+			return 0, p.error("unpaired high surrogate")
+		}
+		p.cursor += 2
+		low, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if low < 0xDC00 || low > 0xDFFF {
+			return 0, p.error("invalid low surrogate")
+		}
+		return (r-0xD800)<<10 + (low - 0xDC00) + 0x10000, nil
+	}
+	return r, nil
+}
+
+func (p *rfcParser) parseLogicalOr() (rfcLogicalExpr, error) {
+	var exprs []rfcLogicalExpr
+	for {
+		expr, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		start := p.cursor
+		p.skipBlank()
+		if !p.peek("||") {
+			p.cursor = start
+			break
+		}
+		p.cursor += 2
+		p.skipBlank()
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return &rfcOrExpr{exprs: exprs}, nil
+}
+
+func (p *rfcParser) parseLogicalAnd() (rfcLogicalExpr, error) {
+	var exprs []rfcLogicalExpr
+	for {
+		expr, err := p.parseBasicExpr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		start := p.cursor
+		p.skipBlank()
+		if !p.peek("&&") {
+			p.cursor = start
+			break
+		}
+		p.cursor += 2
+		p.skipBlank()
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return &rfcAndExpr{exprs: exprs}, nil
+}
+
+func (p *rfcParser) parseBasicExpr() (rfcLogicalExpr, error) {
+	if p.char() == '!' {
+		p.cursor++
+		p.skipBlank()
+		var (
+			expr rfcLogicalExpr
+			err  error
+		)
+		if p.char() == '(' {
+			expr, err = p.parseParenExpr()
+		} else {
+			expr, err = p.parseTestExpr()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &rfcNotExpr{expr: expr}, nil
+	}
+	if p.char() == '(' {
+		return p.parseParenExpr()
+	}
+	start := p.cursor
+	left, leftType, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	opStart := p.cursor
+	p.skipBlank()
+	op := p.parseComparisonOp()
+	if op == "" {
+		p.cursor = opStart
+		return p.toTestExpr(left, leftType, start)
+	}
+	if err := p.checkComparable(left, leftType, start); err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	rightStart := p.cursor
+	right, rightType, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(right, rightType, rightStart); err != nil {
+		return nil, err
+	}
+	return &rfcComparisonExpr{op: op, left: toComparable(left), right: toComparable(right)}, nil
+}
+
+func (p *rfcParser) parseParenExpr() (rfcLogicalExpr, error) {
+	p.cursor++ // '('
+	p.skipBlank()
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.char() != ')' {
+		return nil, p.error("expected )")
+	}
+	p.cursor++
+	return expr, nil
+}
+
+func (p *rfcParser) parseTestExpr() (rfcLogicalExpr, error) {
+	start := p.cursor
+	operand, typ, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return p.toTestExpr(operand, typ, start)
+}
+
+func (p *rfcParser) toTestExpr(operand interface{}, typ rfcType, start int) (rfcLogicalExpr, error) {
+	switch v := operand.(type) {
+	case *rfcQuery:
+		return &rfcExistenceExpr{query: v}, nil
+	case *rfcFunctionExpr:
+		if typ == rfcValueType {
+			p.cursor = start
+			return nil, p.error("result of function %s must be compared", v.function.name)
+		}
+		return v, nil
+	}
+	p.cursor = start
+	return nil, p.error("literal must be compared")
+}
+
+func (p *rfcParser) checkComparable(operand interface{}, typ rfcType, start int) error {
+	switch v := operand.(type) {
+	case *rfcQuery:
+		if !v.singular() {
+			p.cursor = start
+			return p.error("non-singular query is not comparable")
+		}
+	case *rfcFunctionExpr:
+		if typ != rfcValueType {
+			p.cursor = start
+			return p.error("result of function %s is not comparable", v.function.name)
+		}
+	}
+	return nil
+}
+
+func toComparable(operand interface{}) rfcComparable {
+	switch v := operand.(type) {
+	case *rfcQuery:
+		return &rfcSingularQuery{query: v}
+	case *rfcValue:
+		return &rfcLiteral{value: v}
+	case *rfcFunctionExpr:
+		return v
+	}
+	return nil
+}
+
+func (p *rfcParser) parseComparisonOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.peek(op) {
+			p.cursor += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// parseOperand parses a literal, a filter query or a function expression.
+// The returned value is *rfcValue, *rfcQuery or *rfcFunctionExpr.
+func (p *rfcParser) parseOperand() (interface{}, rfcType, error) {
+	switch c := p.char(); {
+	case c == '@' || c == '$':
+		query, err := p.parseFilterQuery()
+		if err != nil {
+			return nil, 0, err
+		}
+		return query, rfcNodesType, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, 0, err
+		}
+		return &rfcValue{kind: rfcString, str: s}, rfcValueType, nil
+	case c == '-' || isRFCDigit(c):
+		v, err := p.parseNumberLiteral()
+		if err != nil {
+			return nil, 0, err
+		}
+		return v, rfcValueType, nil
+	case c >= 'a' && c <= 'z':
+		for _, literal := range []struct {
+			name  string
+			value *rfcValue
+		}{
+			{"true", &rfcValue{kind: rfcTrue}},
+			{"false", &rfcValue{kind: rfcFalse}},
+			{"null", &rfcValue{kind: rfcNull}},
+		} {
+			if p.peek(literal.name) && !p.isFunctionNameChar(p.cursor+len(literal.name)) && !p.peekAt(p.cursor+len(literal.name), '(') {
+				p.cursor += len(literal.name)
+				return literal.value, rfcValueType, nil
+			}
+		}
+		fn, err := p.parseFunctionExpr()
+		if err != nil {
+			return nil, 0, err
+		}
+		return fn, fn.function.result, nil
+	}
+	return nil, 0, p.error("invalid expression")
+}
+
+func (p *rfcParser) peekAt(cursor int, c byte) bool {
+	return cursor < len(p.src) && p.src[cursor] == c
+}
+
+func (p *rfcParser) isFunctionNameChar(cursor int) bool {
+	if cursor >= len(p.src) {
+		return false
+	}
+	c := p.src[cursor]
+	return c >= 'a' && c <= 'z' || c == '_' || isRFCDigit(c)
+}
+
+func (p *rfcParser) parseFilterQuery() (*rfcQuery, error) {
+	relative := p.char() == '@'
+	p.cursor++
+	query, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	query.relative = relative
+	return query, nil
+}
+
+func (p *rfcParser) parseNumberLiteral() (*rfcValue, error) {
+	start := p.cursor
+	if p.peek("-0") {
+		p.cursor += 2
+		if isRFCDigit(p.char()) {
+			return nil, p.error("leading zeros are not allowed")
+		}
+	} else {
+		if _, err := p.parseLiteralInt(); err != nil {
+			return nil, err
+		}
+	}
+	if p.char() == '.' {
+		p.cursor++
+		if !isRFCDigit(p.char()) {
+			return nil, p.error("expected digit after decimal point")
+		}
+		for isRFCDigit(p.char()) {
+			p.cursor++
+		}
+	}
+	if p.char() == 'e' || p.char() == 'E' {
+		p.cursor++
+		if p.char() == '+' || p.char() == '-' {
+			p.cursor++
+		}
+		if !isRFCDigit(p.char()) {
+			return nil, p.error("expected digit in exponent")
+		}
+		for isRFCDigit(p.char()) {
+			p.cursor++
+		}
+	}
+	f, err := strconv.ParseFloat(p.src[start:p.cursor], 64)
+	if err != nil {
+		return nil, p.error("invalid number %s", p.src[start:p.cursor])
+	}
+	return &rfcValue{kind: rfcNumber, num: f}, nil
+}
+
+// parseLiteralInt parses the integer part of a number literal, which isn't limited to the I-JSON range.
+func (p *rfcParser) parseLiteralInt() (string, error) {
+	start := p.cursor
+	if p.char() == '-' {
+		p.cursor++
+	}
+	if !isRFCDigit(p.char()) {
+		return "", p.error("expected digit")
+	}
+	if p.char() == '0' {
+		p.cursor++
+		if isRFCDigit(p.char()) {
+			return "", p.error("leading zeros are not allowed")
+		}
+		return p.src[start:p.cursor], nil
+	}
+	for isRFCDigit(p.char()) {
+		p.cursor++
+	}
+	return p.src[start:p.cursor], nil
+}
+
+func (p *rfcParser) parseFunctionExpr() (*rfcFunctionExpr, error) {
+	start := p.cursor
+	for p.isFunctionNameChar(p.cursor) {
+		p.cursor++
+	}
+	name := p.src[start:p.cursor]
+	if p.char() != '(' {
+		p.cursor = start
+		return nil, p.error("invalid expression")
+	}
+	fn, exists := rfcFunctions[name]
+	if !exists {
+		p.cursor = start
+		return nil, p.error("unknown function %s", name)
+	}
+	p.cursor++ // '('
+	p.skipBlank()
+	expr := &rfcFunctionExpr{function: fn}
+	for i := 0; p.char() != ')'; i++ {
+		if i != 0 {
+			if p.char() != ',' {
+				return nil, p.error("expected , or ) in function arguments")
+			}
+			p.cursor++
+			p.skipBlank()
+		}
+		if i >= len(fn.params) {
+			return nil, p.error("too many arguments for function %s", name)
+		}
+		arg, err := p.parseFunctionArgument(fn.params[i])
+		if err != nil {
+			return nil, err
+		}
+		expr.args = append(expr.args, arg)
+		p.skipBlank()
+	}
+	if len(expr.args) != len(fn.params) {
+		return nil, p.error("too few arguments for function %s", name)
+	}
+	p.cursor++ // ')'
+	if fn.name == "match" || fn.name == "search" {
+		// the literal pattern is compiled once here instead of at every evaluation.
+		if lit, ok := expr.args[1].(*rfcLiteral); ok && lit.value.kind == rfcString {
+			expr.args[1] = &rfcPatternLiteral{
+				rfcLiteral: lit,
+				re:         compileIRegexp(lit.value.str, fn.name == "match"),
+			}
+		}
+	}
+	return expr, nil
+}
+
+// parseFunctionArgument parses an argument and checks that it's well-typed for the parameter type.
+// The returned value is rfcComparable for ValueType, *rfcQuery or *rfcFunctionExpr for NodesType
+// and rfcLogicalExpr for LogicalType.
+func (p *rfcParser) parseFunctionArgument(typ rfcType) (interface{}, error) {
+	start := p.cursor
+	if typ == rfcLogicalType {
+		return p.parseLogicalOr()
+	}
+	operand, operandType, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case rfcValueType:
+		if err := p.checkComparable(operand, operandType, start); err != nil {
+			return nil, err
+		}
+		return toComparable(operand), nil
+	default:
+		if operandType != rfcNodesType {
+			p.cursor = start
+			return nil, p.error("argument must be a query")
+		}
+		return operand, nil
+	}
+}
@@ -0,0 +1,612 @@
+package decoder
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+type rfcKind int
+
+const (
+	rfcNull rfcKind = iota
+	rfcTrue
+	rfcFalse
+	rfcNumber
+	rfcString
+	rfcArray
+	rfcObject
+)
+
+// rfcValue is a JSON value the query is evaluated on, or a literal of the query.
+// A nil *rfcValue represents Nothing, the absence of a value.
+type rfcValue struct {
+	kind rfcKind
+	num  float64
+	str  string
+	// keys are the member names of an object, and elems are the member values or array elements.
+	keys  []string
+	elems []*rfcValue
+	// start and end are the byte offsets of the value in the source.
+	start int64
+	end   int64
+}
+
+func (v *rfcValue) equal(o *rfcValue) bool {
+	if v.kind != o.kind {
+		return false
+	}
+	switch v.kind {
+	case rfcNumber:
+		return v.num == o.num
+	case rfcString:
+		return v.str == o.str
+	case rfcArray:
+		if len(v.elems) != len(o.elems) {
+			return false
+		}
+		for i := range v.elems {
+			if !v.elems[i].equal(o.elems[i]) {
+				return false
+			}
+		}
+	case rfcObject:
+		if len(v.keys) != len(o.keys) {
+			return false
+		}
+		for i, key := range v.keys {
+			member := o.member(key)
+			if member == nil || !v.elems[i].equal(member) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (v *rfcValue) member(key string) *rfcValue {
+	for i, k := range v.keys {
+		if k == key {
+			return v.elems[i]
+		}
+	}
+	return nil
+}
+
+func parseRFCValue(src []byte, cursor, depth int64) (*rfcValue, int64, error) {
+	cursor = skipWhiteSpace(src, cursor)
+	start := cursor
+	switch src[cursor] {
+	case '{':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return nil, 0, errors.ErrExceededMaxDepth(src[cursor], cursor)
+		}
+		v := &rfcValue{kind: rfcObject, start: start}
+		cursor = skipWhiteSpace(src, cursor+1)
+		if src[cursor] == '}' {
+			v.end = cursor + 1
+			return v, v.end, nil
+		}
+		for {
+			key, keyEnd, err := decodePathSetString(src, cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			cursor = skipWhiteSpace(src, keyEnd)
+			if src[cursor] != ':' {
+				return nil, 0, errors.ErrExpected("colon after object key", cursor)
+			}
+			elem, c, err := parseRFCValue(src, cursor+1, depth)
+			if err != nil {
+				return nil, 0, err
+			}
+			v.keys = append(v.keys, string(key))
+			v.elems = append(v.elems, elem)
+			cursor = skipWhiteSpace(src, c)
+			if src[cursor] == '}' {
+				v.end = cursor + 1
+				return v, v.end, nil
+			}
+			if src[cursor] != ',' {
+				return nil, 0, errors.ErrExpected("comma after object value", cursor)
+			}
+			cursor = skipWhiteSpace(src, cursor+1)
+		}
+	case '[':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return nil, 0, errors.ErrExceededMaxDepth(src[cursor], cursor)
+		}
+		v := &rfcValue{kind: rfcArray, start: start}
+		cursor = skipWhiteSpace(src, cursor+1)
+		if src[cursor] == ']' {
+			v.end = cursor + 1
+			return v, v.end, nil
+		}
+		for {
+			elem, c, err := parseRFCValue(src, cursor, depth)
+			if err != nil {
+				return nil, 0, err
+			}
+			v.elems = append(v.elems, elem)
+			cursor = skipWhiteSpace(src, c)
+			if src[cursor] == ']' {
+				v.end = cursor + 1
+				return v, v.end, nil
+			}
+			if src[cursor] != ',' {
+				return nil, 0, errors.ErrInvalidCharacter(src[cursor], "slice", cursor)
+			}
+			cursor++
+		}
+	case '"':
+		s, end, err := decodePathSetString(src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &rfcValue{kind: rfcString, str: string(s), start: start, end: end}, end, nil
+	case nul:
+		return nil, 0, errors.ErrUnexpectedEndOfJSON("value", cursor)
+	}
+	end, err := skipValue(src, cursor, depth)
+	if err != nil {
+		return nil, 0, err
+	}
+	v := &rfcValue{start: start, end: end}
+	switch src[start] {
+	case 't':
+		v.kind = rfcTrue
+	case 'f':
+		v.kind = rfcFalse
+	case 'n':
+		v.kind = rfcNull
+	default:
+		num, err := strconv.ParseFloat(string(src[start:end]), 64)
+		if err != nil {
+			return nil, 0, errors.ErrSyntax("invalid number "+string(src[start:end]), start)
+		}
+		v.kind = rfcNumber
+		v.num = num
+	}
+	return v, end, nil
+}
+
+// rfcPathNode is a node of the nodelist produced by the query, a value together with its location.
+type rfcPathNode struct {
+	value  *rfcValue
+	parent *rfcPathNode
+	loc    pathLocation
+}
+
+func (n *rfcPathNode) child(i int) *rfcPathNode {
+	child := &rfcPathNode{value: n.value.elems[i], parent: n}
+	if n.value.kind == rfcObject {
+		child.loc = pathLocation{field: n.value.keys[i]}
+	} else {
+		child.loc = pathLocation{index: i, isIndex: true}
+	}
+	return child
+}
+
+func (n *rfcPathNode) location() []pathLocation {
+	var location []pathLocation
+	for node := n; node.parent != nil; node = node.parent {
+		location = append(location, node.loc)
+	}
+	for i, j := 0, len(location)-1; i < j; i, j = i+1, j-1 {
+		location[i], location[j] = location[j], location[i]
+	}
+	return location
+}
+
+type rfcEvalContext struct {
+	root *rfcPathNode
+}
+
+// matches expects src to be terminated by a nul byte.
+func (q *rfcQuery) matches(src []byte) ([]PathMatch, error) {
+	root, cursor, err := parseRFCValue(src, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEndOfValue(src, cursor); err != nil {
+		return nil, err
+	}
+	ctx := &rfcEvalContext{root: &rfcPathNode{value: root}}
+	nodes := q.eval(ctx, ctx.root)
+	matches := make([]PathMatch, 0, len(nodes))
+	for _, node := range nodes {
+		matches = append(matches, PathMatch{
+			Value:          src[node.value.start:node.value.end],
+			NormalizedPath: normalizedPath(node.location()),
+			Start:          node.value.start,
+			End:            node.value.end,
+		})
+	}
+	return matches, nil
+}
+
+func (q *rfcQuery) eval(ctx *rfcEvalContext, current *rfcPathNode) []*rfcPathNode {
+	nodes := []*rfcPathNode{ctx.root}
+	if q.relative {
+		nodes = []*rfcPathNode{current}
+	}
+	for _, seg := range q.segments {
+		var selected []*rfcPathNode
+		for _, node := range nodes {
+			selected = seg.apply(ctx, node, selected)
+		}
+		nodes = selected
+	}
+	return nodes
+}
+
+func (s *rfcSegment) apply(ctx *rfcEvalContext, node *rfcPathNode, selected []*rfcPathNode) []*rfcPathNode {
+	for _, sel := range s.selectors {
+		selected = sel.apply(ctx, node, selected)
+	}
+	if s.descendant {
+		// the descendants are visited after their parent, and array elements in order.
+		for i := range node.value.elems {
+			selected = s.apply(ctx, node.child(i), selected)
+		}
+	}
+	return selected
+}
+
+func (s *rfcSelector) apply(ctx *rfcEvalContext, node *rfcPathNode, selected []*rfcPathNode) []*rfcPathNode {
+	v := node.value
+	switch s.typ {
+	case rfcNameSelector:
+		if v.kind != rfcObject {
+			return selected
+		}
+		for i, key := range v.keys {
+			if key == s.name {
+				selected = append(selected, node.child(i))
+			}
+		}
+	case rfcWildcardSelector:
+		for i := range v.elems {
+			selected = append(selected, node.child(i))
+		}
+	case rfcIndexSelector:
+		if v.kind != rfcArray {
+			return selected
+		}
+		idx := s.index
+		if idx < 0 {
+			idx += int64(len(v.elems))
+		}
+		if idx >= 0 && idx < int64(len(v.elems)) {
+			selected = append(selected, node.child(int(idx)))
+		}
+	case rfcSliceSelector:
+		if v.kind != rfcArray {
+			return selected
+		}
+		for _, idx := range s.sliceIndexes(int64(len(v.elems))) {
+			selected = append(selected, node.child(int(idx)))
+		}
+	case rfcFilterSelector:
+		if v.kind != rfcArray && v.kind != rfcObject {
+			return selected
+		}
+		for i := range v.elems {
+			child := node.child(i)
+			if s.filter.evalLogical(ctx, child) {
+				selected = append(selected, child)
+			}
+		}
+	}
+	return selected
+}
+
+// sliceIndexes returns the indexes selected by the slice selector for an array of length n.
+func (s *rfcSelector) sliceIndexes(n int64) []int64 {
+	step := int64(1)
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return nil
+	}
+	normalize := func(i int64) int64 {
+		if i >= 0 {
+			return i
+		}
+		return n + i
+	}
+	clamp := func(i, lower, upper int64) int64 {
+		if i < lower {
+			return lower
+		}
+		if i > upper {
+			return upper
+		}
+		return i
+	}
+	var indexes []int64
+	if step > 0 {
+		start, end := int64(0), n
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		lower, upper := clamp(start, 0, n), clamp(end, 0, n)
+		for i := lower; i < upper; i += step {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	start, end := n-1, -n-1
+	if s.start != nil {
+		start = normalize(*s.start)
+	}
+	if s.end != nil {
+		end = normalize(*s.end)
+	}
+	upper, lower := clamp(start, -1, n-1), clamp(end, -1, n-1)
+	for i := upper; lower < i; i += step {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func (e *rfcOrExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	for _, expr := range e.exprs {
+		if expr.evalLogical(ctx, current) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *rfcAndExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	for _, expr := range e.exprs {
+		if !expr.evalLogical(ctx, current) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *rfcNotExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	return !e.expr.evalLogical(ctx, current)
+}
+
+func (e *rfcExistenceExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	return len(e.query.eval(ctx, current)) != 0
+}
+
+func (e *rfcComparisonExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	left := e.left.evalValue(ctx, current)
+	right := e.right.evalValue(ctx, current)
+	switch e.op {
+	case "==":
+		return rfcEqual(left, right)
+	case "!=":
+		return !rfcEqual(left, right)
+	case "<":
+		return rfcLess(left, right)
+	case "<=":
+		return rfcLess(left, right) || rfcEqual(left, right)
+	case ">":
+		return rfcLess(right, left)
+	case ">=":
+		return rfcLess(right, left) || rfcEqual(left, right)
+	}
+	return false
+}
+
+func rfcEqual(left, right *rfcValue) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	return left.equal(right)
+}
+
+func rfcLess(left, right *rfcValue) bool {
+	if left == nil || right == nil || left.kind != right.kind {
+		return false
+	}
+	switch left.kind {
+	case rfcNumber:
+		return left.num < right.num
+	case rfcString:
+		// comparing UTF-8 bytes is the same as comparing Unicode scalar values.
+		return left.str < right.str
+	}
+	return false
+}
+
+func (e *rfcLiteral) evalValue(ctx *rfcEvalContext, current *rfcPathNode) *rfcValue {
+	return e.value
+}
+
+func (e *rfcSingularQuery) evalValue(ctx *rfcEvalContext, current *rfcPathNode) *rfcValue {
+	nodes := e.query.eval(ctx, current)
+	if len(nodes) != 1 {
+		return nil
+	}
+	return nodes[0].value
+}
+
+func (e *rfcFunctionExpr) evalValue(ctx *rfcEvalContext, current *rfcPathNode) *rfcValue {
+	value, _ := e.function.call(ctx, current, e.args)
+	return value
+}
+
+func (e *rfcFunctionExpr) evalLogical(ctx *rfcEvalContext, current *rfcPathNode) bool {
+	_, logical := e.function.call(ctx, current, e.args)
+	return logical
+}
+
+// rfcFunction is a function extension.
+// call returns the result as *rfcValue for ValueType, and as bool for LogicalType.
+type rfcFunction struct {
+	name   string
+	params []rfcType
+	result rfcType
+	call   func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool)
+}
+
+var rfcFunctions = map[string]*rfcFunction{
+	"length": {
+		name:   "length",
+		params: []rfcType{rfcValueType},
+		result: rfcValueType,
+		call: func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool) {
+			v := args[0].(rfcComparable).evalValue(ctx, current)
+			if v == nil {
+				return nil, false
+			}
+			switch v.kind {
+			case rfcString:
+				return &rfcValue{kind: rfcNumber, num: float64(utf8.RuneCountInString(v.str))}, false
+			case rfcArray, rfcObject:
+				return &rfcValue{kind: rfcNumber, num: float64(len(v.elems))}, false
+			}
+			return nil, false
+		},
+	},
+	"count": {
+		name:   "count",
+		params: []rfcType{rfcNodesType},
+		result: rfcValueType,
+		call: func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool) {
+			nodes := args[0].(*rfcQuery).eval(ctx, current)
+			return &rfcValue{kind: rfcNumber, num: float64(len(nodes))}, false
+		},
+	},
+	"match": {
+		name:   "match",
+		params: []rfcType{rfcValueType, rfcValueType},
+		result: rfcLogicalType,
+		call: func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool) {
+			return nil, rfcRegexpMatch(ctx, current, args, true)
+		},
+	},
+	"search": {
+		name:   "search",
+		params: []rfcType{rfcValueType, rfcValueType},
+		result: rfcLogicalType,
+		call: func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool) {
+			return nil, rfcRegexpMatch(ctx, current, args, false)
+		},
+	},
+	"value": {
+		name:   "value",
+		params: []rfcType{rfcNodesType},
+		result: rfcValueType,
+		call: func(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}) (*rfcValue, bool) {
+			nodes := args[0].(*rfcQuery).eval(ctx, current)
+			if len(nodes) != 1 {
+				return nil, false
+			}
+			return nodes[0].value, false
+		},
+	},
+}
+
+func rfcRegexpMatch(ctx *rfcEvalContext, current *rfcPathNode, args []interface{}, full bool) bool {
+	v := args[0].(rfcComparable).evalValue(ctx, current)
+	if v == nil || v.kind != rfcString {
+		return false
+	}
+	var re *regexp.Regexp
+	if lit, ok := args[1].(*rfcPatternLiteral); ok {
+		re = lit.re
+	} else {
+		// the pattern produced by a query can differ for every node, so it's compiled each time.
+		pattern := args[1].(rfcComparable).evalValue(ctx, current)
+		if pattern == nil || pattern.kind != rfcString {
+			return false
+		}
+		re = compileIRegexp(pattern.str, full)
+	}
+	if re == nil {
+		return false
+	}
+	return re.MatchString(v.str)
+}
+
+// compileIRegexp compiles the I-Regexp (RFC 9485) pattern into the Go regular expression.
+// It returns nil if the pattern isn't valid.
+func compileIRegexp(pattern string, full bool) *regexp.Regexp {
+	expr, ok := translateIRegexp(pattern)
+	if !ok {
+		return nil
+	}
+	if full {
+		expr = `\A(?:` + expr + `)\z`
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// translateIRegexp rewrites the constructs whose meaning differs between I-Regexp and Go.
+// The dot of I-Regexp doesn't match the carriage return either,
+// and the escapes and character classes unknown to I-Regexp are rejected.
+func translateIRegexp(pattern string) (string, bool) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(pattern) {
+				return "", false
+			}
+			next := pattern[i+1]
+			switch next {
+			case 'p', 'P':
+				b.WriteByte(c)
+			case '(', ')', '*', '+', '.', '-', '?', '[', '\\', ']', '^', '{', '|', '}', 'n', 'r', 't':
+				b.WriteByte(c)
+			default:
+				return "", false
+			}
+			b.WriteByte(next)
+			i++
+		case c == '[' && !inClass:
+			inClass = true
+			b.WriteByte(c)
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				b.WriteByte('^')
+				i++
+			}
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				// a leading ] is a literal in Go, but I-Regexp doesn't allow an empty class.
+				return "", false
+			}
+		case c == ']' && inClass:
+			inClass = false
+			b.WriteByte(c)
+		case c == '.' && !inClass:
+			b.WriteString(`[^\n\r]`)
+		case (c == '^' || c == '$') && !inClass:
+			// I-Regexp has no anchors, these are literal characters.
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '(' && !inClass && i+1 < len(pattern) && pattern[i+1] == '?':
+			// groups with modifiers such as (?: and (?i) aren't I-Regexp.
+			return "", false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if inClass {
+		return "", false
+	}
+	return b.String(), true
+}
@@ -0,0 +1,301 @@
+package decoder
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// PathSet is a set of JSON Paths extracted together in a single pass over the input.
+// Each path is matched by its own selector nodes in the same way as the path decoder does for Path.Extract,
+// so that the results are the same as extracting the paths one by one.
+type PathSet struct {
+	paths []*Path
+	// single reports whether each path can match at most one value.
+	single []bool
+}
+
+// pathSetState is the selector node that a path of the set reached at the current value.
+type pathSetState struct {
+	target int
+	node   PathNode
+}
+
+var pathSetScalarDecoder = NewPathDecoder().(*interfaceDecoder)
+
+// NewPathSet creates a PathSet from paths.
+func NewPathSet(paths []*Path) *PathSet {
+	set := &PathSet{
+		paths:  paths,
+		single: make([]bool, len(paths)),
+	}
+	for i, path := range paths {
+		set.single[i] = path.Singular()
+	}
+	return set
+}
+
+type pathSetContext struct {
+	rctx    *RuntimeContext
+	results [][][]byte
+	matched []bool
+	// remain is the number of single valued paths that haven't matched yet.
+	// It is negative when the set contains paths that can match many values, which disables early stopping.
+	remain int
+	// completed is set once every path has matched, and the rest of the input is no longer scanned.
+	completed bool
+}
+
+// Extract extracts the values corresponding to each path of the set from data in a single pass.
+// The results are in the same order as the paths of the set.
+// The single valued paths stop matching at the first match, so only the first member is extracted for the duplicated keys.
+func (s *PathSet) Extract(data []byte) ([][][]byte, error) {
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	ctx := &pathSetContext{
+		rctx:    TakeRuntimeContext(),
+		results: make([][][]byte, len(s.paths)),
+		matched: make([]bool, len(s.paths)),
+	}
+	defer ReleaseRuntimeContext(ctx.rctx)
+	ctx.rctx.Buf = src
+
+	states := make([]pathSetState, 0, len(s.paths))
+	for i, path := range s.paths {
+		if path.RootSelectorOnly {
+			// the root is extracted as is in the same way as Path.Extract.
+			ctx.results[i] = [][]byte{data}
+			ctx.matched[i] = true
+			continue
+		}
+		states = append(states, pathSetState{target: i, node: path.node})
+		if ctx.remain >= 0 {
+			if s.single[i] {
+				ctx.remain++
+			} else {
+				ctx.remain = -1
+			}
+		}
+	}
+	if len(states) == 0 {
+		return ctx.results, nil
+	}
+	cursor, err := s.decodePath(ctx, states, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.completed {
+		return ctx.results, nil
+	}
+	if err := validateEndOfValue(src, cursor); err != nil {
+		return nil, err
+	}
+	return ctx.results, nil
+}
+
+func (s *PathSet) add(ctx *pathSetContext, target int, value []byte) {
+	if s.single[target] {
+		if ctx.matched[target] {
+			return
+		}
+		ctx.matched[target] = true
+		ctx.remain--
+		if ctx.remain == 0 {
+			ctx.completed = true
+		}
+	}
+	ctx.results[target] = append(ctx.results[target], value)
+}
+
+func (s *PathSet) decodePath(ctx *pathSetContext, states []pathSetState, cursor, depth int64) (int64, error) {
+	buf := ctx.rctx.Buf
+	cursor = skipWhiteSpace(buf, cursor)
+	switch buf[cursor] {
+	case '{':
+		return s.decodeObject(ctx, states, cursor, depth)
+	case '[':
+		return s.decodeArray(ctx, states, cursor, depth)
+	}
+	// the scalar value reached before the last selector is extracted as decoded by the path decoder.
+	var (
+		value []byte
+		end   int64
+		err   error
+	)
+	if buf[cursor] == '"' {
+		value, end, err = decodePathSetString(buf, cursor)
+	} else {
+		var values [][]byte
+		values, end, err = pathSetScalarDecoder.decodeScalarPath(ctx.rctx, cursor, depth)
+		if len(values) > 0 {
+			value = values[0]
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		value = nullbytes
+	}
+	for _, state := range states {
+		s.add(ctx, state.target, value)
+	}
+	return end, nil
+}
+
+// decodeChild decodes the value at cursor for the states reached by the selector,
+// and adds the value to the targets, whose paths end at the selector.
+func (s *PathSet) decodeChild(ctx *pathSetContext, children []pathSetState, targets []int, cursor, depth int64) (int64, error) {
+	var (
+		end int64
+		err error
+	)
+	if len(children) == 0 {
+		end, err = skipValue(ctx.rctx.Buf, cursor, depth)
+	} else {
+		end, err = s.decodePath(ctx, children, cursor, depth)
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, target := range targets {
+		s.add(ctx, target, ctx.rctx.Buf[cursor:end])
+	}
+	return end, nil
+}
+
+func (s *PathSet) decodeObject(ctx *pathSetContext, states []pathSetState, cursor, depth int64) (int64, error) {
+	buf := ctx.rctx.Buf
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+	}
+	cursor++
+	cursor = skipWhiteSpace(buf, cursor)
+	if buf[cursor] == '}' {
+		return cursor + 1, nil
+	}
+	for {
+		key, keyCursor, err := decodePathSetString(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		cursor = skipWhiteSpace(buf, keyCursor)
+		if buf[cursor] != ':' {
+			return 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		cursor++
+		var (
+			children []pathSetState
+			targets  []int
+		)
+		name := string(key)
+		for _, state := range states {
+			if s.single[state.target] && ctx.matched[state.target] {
+				continue
+			}
+			child, found, err := state.node.Field(name)
+			if err != nil {
+				return 0, err
+			}
+			if !found {
+				continue
+			}
+			if child == nil {
+				targets = append(targets, state.target)
+			} else {
+				children = append(children, pathSetState{target: state.target, node: child})
+			}
+		}
+		c, err := s.decodeChild(ctx, children, targets, cursor, depth)
+		if err != nil {
+			return 0, err
+		}
+		if ctx.completed {
+			return c, nil
+		}
+		cursor = skipWhiteSpace(buf, c)
+		if buf[cursor] == '}' {
+			return cursor + 1, nil
+		}
+		if buf[cursor] != ',' {
+			return 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+	}
+}
+
+func (s *PathSet) decodeArray(ctx *pathSetContext, states []pathSetState, cursor, depth int64) (int64, error) {
+	buf := ctx.rctx.Buf
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+	}
+	cursor++
+	cursor = skipWhiteSpace(buf, cursor)
+	if buf[cursor] == ']' {
+		return cursor + 1, nil
+	}
+	for idx := 0; ; idx++ {
+		var (
+			children []pathSetState
+			targets  []int
+		)
+		for _, state := range states {
+			if s.single[state.target] && ctx.matched[state.target] {
+				continue
+			}
+			child, found, err := state.node.Index(idx)
+			if err != nil {
+				return 0, err
+			}
+			if !found {
+				continue
+			}
+			if child == nil {
+				targets = append(targets, state.target)
+			} else {
+				children = append(children, pathSetState{target: state.target, node: child})
+			}
+		}
+		c, err := s.decodeChild(ctx, children, targets, cursor, depth)
+		if err != nil {
+			return 0, err
+		}
+		if ctx.completed {
+			return c, nil
+		}
+		cursor = skipWhiteSpace(buf, c)
+		switch buf[cursor] {
+		case ']':
+			return cursor + 1, nil
+		case ',':
+			cursor++
+		default:
+			return 0, errors.ErrInvalidCharacter(buf[cursor], "slice", cursor)
+		}
+	}
+}
+
+// decodePathSetString decodes the string at cursor without unescaping it in place,
+// because the raw bytes can be a part of another value extracted or edited from the same buffer.
+func decodePathSetString(buf []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	if buf[cursor] != '"' {
+		return pathKeyDecoder.decodeByte(buf, cursor)
+	}
+	end, err := skipValue(buf, cursor, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if bytes.IndexByte(buf[cursor:end], '\\') < 0 {
+		return buf[cursor+1 : end-1], end, nil
+	}
+	src := make([]byte, end-cursor+1) // append nul byte to the end
+	copy(src, buf[cursor:end])
+	value, _, err := pathKeyDecoder.decodeByte(src, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, end, nil
+}
@@ -0,0 +1,154 @@
+package decoder
+
+import (
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// PathStreamFunc is called for each value matched by JSON Path while reading a stream.
+// The stream is positioned at the beginning of the matched value and the function must consume it.
+type PathStreamFunc func(s *Stream, depth int64) error
+
+// DecodeStream reads the next value from s and calls fn for each value matched by the path.
+// Values that don't match are skipped, and the bytes consumed by them are dropped from the buffer
+// so that arbitrarily large inputs can be processed with a bounded buffer.
+func (p *Path) DecodeStream(s *Stream, fn PathStreamFunc) error {
+	if p.query != nil {
+		return errors.ErrInvalidPath("streaming is not supported by RFC 9535 path")
+	}
+	discardOnRead := s.discardOnRead
+	defer func() {
+		s.discardOnRead = discardOnRead
+	}()
+	if p.node == nil {
+		return fn(s, 0)
+	}
+	s.discardOnRead = true
+	return p.decodeStream(s, p.node, 0, fn)
+}
+
+func (p *Path) decodeStream(s *Stream, node PathNode, depth int64, fn PathStreamFunc) error {
+	switch s.skipWhiteSpace() {
+	case '{':
+		return p.decodeStreamObject(s, node, depth, fn)
+	case '[':
+		return p.decodeStreamArray(s, node, depth, fn)
+	case nul:
+		return errors.ErrUnexpectedEndOfJSON("path", s.totalOffset())
+	}
+	if n, ok := node.(*PathSelectorNode); ok && n.recursive && n.child == nil {
+		return p.matchStream(s, depth, fn)
+	}
+	return s.skipValue(depth)
+}
+
+func (p *Path) matchStream(s *Stream, depth int64, fn PathStreamFunc) error {
+	// fn may keep references to the buffer while reading the matched value,
+	// so drop the consumed bytes beforehand and stop discarding until it returns.
+	s.discardConsumed()
+	s.discardOnRead = false
+	defer func() {
+		s.discardOnRead = true
+	}()
+	return fn(s, depth)
+}
+
+func (p *Path) decodeStreamObject(s *Stream, node PathNode, depth int64, fn PathStreamFunc) error {
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return errors.ErrExceededMaxDepth(s.char(), s.cursor)
+	}
+	s.cursor++
+	if s.skipWhiteSpace() == '}' {
+		s.cursor++
+		return nil
+	}
+	for {
+		if s.skipWhiteSpace() != '"' {
+			return errors.ErrExpected("object key", s.totalOffset())
+		}
+		// the key refers to the buffer, so it must not be discarded until it's compared.
+		s.discardOnRead = false
+		key, err := stringBytes(s)
+		if err != nil {
+			return err
+		}
+		child, found, err := node.Field(string(key))
+		s.discardOnRead = true
+		if err != nil {
+			return err
+		}
+		if s.skipWhiteSpace() != ':' {
+			return errors.ErrExpected("colon after object key", s.totalOffset())
+		}
+		s.cursor++
+		if err := p.decodeStreamChild(s, child, found, depth, fn); err != nil {
+			return err
+		}
+		switch s.skipWhiteSpace() {
+		case '}':
+			s.cursor++
+			return nil
+		case ',':
+			s.cursor++
+		default:
+			return errors.ErrExpected("comma after object value", s.totalOffset())
+		}
+	}
+}
+
+func (p *Path) decodeStreamArray(s *Stream, node PathNode, depth int64, fn PathStreamFunc) error {
+	depth++
+	if depth > maxDecodeNestingDepth {
+		return errors.ErrExceededMaxDepth(s.char(), s.cursor)
+	}
+	s.cursor++
+	if s.skipWhiteSpace() == ']' {
+		s.cursor++
+		return nil
+	}
+	for idx := 0; ; idx++ {
+		child, found, err := node.Index(idx)
+		if err != nil {
+			return err
+		}
+		if err := p.decodeStreamChild(s, child, found, depth, fn); err != nil {
+			return err
+		}
+		switch s.skipWhiteSpace() {
+		case ']':
+			s.cursor++
+			return nil
+		case ',':
+			s.cursor++
+		default:
+			return errors.ErrInvalidCharacter(s.char(), "slice", s.totalOffset())
+		}
+	}
+}
+
+func (p *Path) decodeStreamChild(s *Stream, child PathNode, found bool, depth int64, fn PathStreamFunc) error {
+	if !found {
+		return s.skipValue(depth)
+	}
+	if child != nil {
+		return p.decodeStream(s, child, depth, fn)
+	}
+	if s.skipWhiteSpace() == nul {
+		return errors.ErrUnexpectedEndOfJSON("path", s.totalOffset())
+	}
+	return p.matchStream(s, depth, fn)
+}
+
+// RawValue reads the next value from the stream and returns a copy of its bytes.
+func (s *Stream) RawValue(depth int64) ([]byte, error) {
+	if s.skipWhiteSpace() == nul {
+		return nil, errors.ErrUnexpectedEndOfJSON("value", s.totalOffset())
+	}
+	start := s.cursor
+	if err := s.skipValue(depth); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, s.cursor-start)
+	copy(raw, s.buf[start:s.cursor])
+	return raw, nil
+}
@@ -0,0 +1,118 @@
+package decoder
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+type queryCacheKey struct {
+	typeptr uintptr
+	hash    string
+}
+
+var (
+	queryCacheMu sync.RWMutex
+	queryCache   = map[queryCacheKey]Decoder{}
+)
+
+// GetFilteredDecoderIfNeeded returns the decoder that decodes only the fields selected by the FieldQuery
+// set to the context of the option. The filtered decoders are cached for each type and query hash.
+func GetFilteredDecoderIfNeeded(typ *runtime.Type, dec Decoder, opt *Option) Decoder {
+	if (opt.Flags & ContextOption) == 0 {
+		return dec
+	}
+	query := encoder.FieldQueryFromContext(opt.Context)
+	if query == nil {
+		return dec
+	}
+	key := queryCacheKey{typeptr: uintptr(unsafe.Pointer(typ)), hash: query.Hash()}
+	queryCacheMu.RLock()
+	cacheDec, exists := queryCache[key]
+	queryCacheMu.RUnlock()
+	if exists {
+		return cacheDec
+	}
+	queryDec := filterDecoder(dec, query)
+	queryCacheMu.Lock()
+	queryCache[key] = queryDec
+	queryCacheMu.Unlock()
+	return queryDec
+}
+
+// filterDecoder applies the query to the struct decoders reached from dec.
+// Pointers, slices, arrays and map values are traversed, so the query applies to their struct elements.
+func filterDecoder(dec Decoder, query *encoder.FieldQuery) Decoder {
+	switch d := dec.(type) {
+	case *structDecoder:
+		return d.filter(query)
+	case *ptrDecoder:
+		return newPtrDecoder(filterDecoder(d.dec, query), d.typ, d.structName, d.fieldName)
+	case *sliceDecoder:
+		return newSliceDecoder(filterDecoder(d.valueDecoder, query), d.elemType, d.size, d.structName, d.fieldName)
+	case *arrayDecoder:
+		return newArrayDecoder(filterDecoder(d.valueDecoder, query), d.elemType, d.alen, d.structName, d.fieldName)
+	case *mapDecoder:
+		return newMapDecoder(
+			d.mapType, d.keyType, d.keyDecoder,
+			d.valueType, filterDecoder(d.valueDecoder, query),
+			d.structName, d.fieldName,
+		)
+	case *anonymousFieldDecoder:
+		return newAnonymousFieldDecoder(d.structType, d.offset, filterDecoder(d.dec, query))
+	}
+	return dec
+}
+
+func (d *structDecoder) filter(query *encoder.FieldQuery) *structDecoder {
+	fieldMap := make(map[string]*structFieldSet, len(d.fieldMap))
+	// the same field set is registered with its key and lower case key.
+	filteredSets := map[*structFieldSet]*structFieldSet{}
+	for k, set := range d.fieldMap {
+		filtered, exists := filteredSets[set]
+		if !exists {
+			filtered = &structFieldSet{
+				dec:         set.dec,
+				offset:      set.offset,
+				isTaggedKey: set.isTaggedKey,
+				key:         set.key,
+				keyLen:      set.keyLen,
+				err:         set.err,
+			}
+			fieldQuery, exists := query.SubQuery(set.key)
+			if !exists {
+				// keep the field known, so that it's skipped even if unknown fields are disallowed.
+				filtered.dec = skipFieldDecoder{}
+				filtered.err = nil
+			} else if fieldQuery != nil {
+				filtered.dec = filterDecoder(set.dec, fieldQuery)
+			}
+			filteredSets[set] = filtered
+		}
+		fieldMap[k] = filtered
+	}
+	structDec := newStructDecoder(d.structName, d.fieldName, fieldMap)
+	structDec.tryOptimize()
+	return structDec
+}
+
+// skipFieldDecoder skips the value of the field excluded by FieldQuery without allocating it.
+type skipFieldDecoder struct{}
+
+func (skipFieldDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, _ unsafe.Pointer) (int64, error) {
+	return skipValue(ctx.Buf, cursor, depth)
+}
+
+func (skipFieldDecoder) DecodeStream(s *Stream, depth int64, _ unsafe.Pointer) error {
+	return s.skipValue(depth)
+}
+
+func (skipFieldDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	cursor, err := skipValue(ctx.Buf, cursor, depth)
+	if err != nil {
+		return nil, 0, err
+	}
+	return nil, cursor, nil
+}
@@ -0,0 +1,628 @@
+package decoder
+
+import (
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// The relaxed syntax of JSONC and JSON5 accepted by RelaxedOption is the comments ( // and /* */ ),
+// the trailing commas in objects and arrays, the unquoted object keys and the single-quoted strings.
+// The white spaces and the comments are skipped by skipWhiteSpaceWithOption, and the object and array decoders
+// accept the rest of the syntax, so the input is decoded as is and the offsets in errors are of the input.
+
+func isRelaxed(opt *Option) bool {
+	return opt.Flags&RelaxedOption != 0
+}
+
+// isRelaxedKeyStart reports whether c begins the unquoted or single-quoted object key.
+func isRelaxedKeyStart(c byte) bool {
+	return c == '\'' || isIdentChar(c)
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+		c >= 0x80
+}
+
+// SkipRelaxedWhiteSpace skips the white spaces and the comments from cursor.
+func SkipRelaxedWhiteSpace(buf []byte, cursor int64) (int64, error) {
+	for {
+		cursor = skipWhiteSpace(buf, cursor)
+		if buf[cursor] != '/' {
+			return cursor, nil
+		}
+		c, err := skipComment(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		if c == cursor {
+			// not a comment, so it's left to be reported by the caller.
+			return cursor, nil
+		}
+		cursor = c
+	}
+}
+
+// skipComment skips the comment beginning with '/' at cursor.
+// cursor is returned as is if it's not a comment.
+func skipComment(buf []byte, cursor int64) (int64, error) {
+	start := cursor
+	switch buf[cursor+1] {
+	case '/':
+		for cursor += 2; ; cursor++ {
+			switch buf[cursor] {
+			case '\n':
+				return cursor + 1, nil
+			case nul:
+				return cursor, nil
+			}
+		}
+	case '*':
+		for cursor += 2; ; cursor++ {
+			switch buf[cursor] {
+			case '*':
+				if buf[cursor+1] == '/' {
+					return cursor + 2, nil
+				}
+			case nul:
+				return 0, errors.ErrSyntax("json: unterminated comment", start)
+			}
+		}
+	}
+	return cursor, nil
+}
+
+// skipWhiteSpaceWithOption skips the white spaces, and the comments too if RelaxedOption is set.
+func skipWhiteSpaceWithOption(ctx *RuntimeContext, cursor int64) (int64, error) {
+	if !isRelaxed(ctx.Option) {
+		return skipWhiteSpace(ctx.Buf, cursor), nil
+	}
+	return SkipRelaxedWhiteSpace(ctx.Buf, cursor)
+}
+
+// skipTrailingComma skips the comma at cursor if the object or array is closed by end after it.
+func skipTrailingComma(buf []byte, cursor int64, end byte) (int64, error) {
+	if buf[cursor] != ',' {
+		return cursor, nil
+	}
+	next, err := SkipRelaxedWhiteSpace(buf, cursor+1)
+	if err != nil {
+		return 0, err
+	}
+	if buf[next] != end {
+		return cursor, nil
+	}
+	return next, nil
+}
+
+// skipValueWithOption skips the value at cursor, which can be of the relaxed syntax if RelaxedOption is set.
+func skipValueWithOption(ctx *RuntimeContext, cursor, depth int64) (int64, error) {
+	if !isRelaxed(ctx.Option) {
+		return skipValue(ctx.Buf, cursor, depth)
+	}
+	buf := ctx.Buf
+	cursor, err := SkipRelaxedWhiteSpace(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	switch buf[cursor] {
+	case '{', '[':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		}
+		return skipRelaxedNest(buf, cursor+1, depth)
+	case '\'':
+		_, end, err := appendSingleQuotedString(nil, buf, cursor)
+		return end, err
+	}
+	return skipValue(buf, cursor, depth)
+}
+
+// skipObjectWithOption skips the rest of the object whose members are being decoded.
+func skipObjectWithOption(ctx *RuntimeContext, cursor, depth int64) (int64, error) {
+	if !isRelaxed(ctx.Option) {
+		return skipObject(ctx.Buf, cursor, depth)
+	}
+	return skipRelaxedNest(ctx.Buf, cursor, depth)
+}
+
+// skipRelaxedNest skips the rest of the object or array of the relaxed syntax after cursor.
+func skipRelaxedNest(buf []byte, cursor, depth int64) (int64, error) {
+	nest := 1
+	for {
+		switch buf[cursor] {
+		case '{', '[':
+			nest++
+			depth++
+			if depth > maxDecodeNestingDepth {
+				return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+			}
+		case '}', ']':
+			nest--
+			depth--
+			if nest == 0 {
+				return cursor + 1, nil
+			}
+		case '"':
+			end, err := skipValue(buf, cursor, depth)
+			if err != nil {
+				return 0, err
+			}
+			cursor = end
+			continue
+		case '\'':
+			_, end, err := appendSingleQuotedString(nil, buf, cursor)
+			if err != nil {
+				return 0, err
+			}
+			cursor = end
+			continue
+		case '/':
+			end, err := skipComment(buf, cursor)
+			if err != nil {
+				return 0, err
+			}
+			if end == cursor {
+				return 0, errors.ErrInvalidCharacter(buf[cursor], "value", cursor)
+			}
+			cursor = end
+			continue
+		case nul:
+			return 0, errors.ErrUnexpectedEndOfJSON("value", cursor)
+		}
+		cursor++
+	}
+}
+
+// decodeRelaxedKey decodes the unquoted or single-quoted object key at cursor.
+func decodeRelaxedKey(buf []byte, cursor int64) ([]byte, int64, error) {
+	if buf[cursor] == '\'' {
+		return decodeSingleQuotedString(buf, cursor)
+	}
+	start := cursor
+	for isIdentChar(buf[cursor]) {
+		cursor++
+	}
+	return buf[start:cursor], cursor, nil
+}
+
+// quoteRelaxedKey converts the unquoted or single-quoted object key at cursor into the double-quoted JSON string,
+// which is terminated by nul byte to be decoded by the key decoders.
+func quoteRelaxedKey(buf []byte, cursor int64) ([]byte, int64, error) {
+	var (
+		quoted []byte
+		end    int64
+		err    error
+	)
+	if buf[cursor] == '\'' {
+		quoted, end, err = appendSingleQuotedString(nil, buf, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		var key []byte
+		key, end, _ = decodeRelaxedKey(buf, cursor)
+		quoted = append(append(append(make([]byte, 0, len(key)+3), '"'), key...), '"')
+	}
+	return append(quoted, nul), end, nil
+}
+
+// decodeSingleQuotedString decodes the single-quoted string at cursor.
+func decodeSingleQuotedString(buf []byte, cursor int64) ([]byte, int64, error) {
+	quoted, end, err := appendSingleQuotedString(nil, buf, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	s, ok := unquoteBytes(quoted)
+	if !ok {
+		return nil, 0, errors.ErrSyntax("json: invalid single-quoted string", cursor)
+	}
+	return s, end, nil
+}
+
+// appendSingleQuotedString appends the single-quoted string at cursor to dst as the double-quoted JSON string,
+// so that it's unescaped in the same way.
+func appendSingleQuotedString(dst, buf []byte, cursor int64) ([]byte, int64, error) {
+	dst = append(dst, '"')
+	for cursor++; ; cursor++ {
+		switch c := buf[cursor]; c {
+		case '\\':
+			cursor++
+			switch buf[cursor] {
+			case '\'':
+				dst = append(dst, '\'')
+			case nul:
+				return nil, 0, errors.ErrUnexpectedEndOfJSON("string", cursor)
+			default:
+				dst = append(dst, '\\', buf[cursor])
+			}
+		case '"':
+			dst = append(dst, '\\', '"')
+		case '\'':
+			return append(dst, '"'), cursor + 1, nil
+		case nul:
+			return nil, 0, errors.ErrUnexpectedEndOfJSON("string", cursor)
+		default:
+			dst = append(dst, c)
+		}
+	}
+}
+
+// relaxedToJSON converts the value of the relaxed syntax into JSON for json.Unmarshaler.
+// src is the value already skipped by skipValueWithOption, so it's well-formed.
+func relaxedToJSON(src []byte) ([]byte, error) {
+	buf := make([]byte, len(src)+1) // append nul byte to the end
+	copy(buf, src)
+	dst := make([]byte, 0, len(src))
+	var (
+		nests     []byte
+		expectKey bool
+		cursor    int64
+	)
+	for buf[cursor] != nul {
+		switch c := buf[cursor]; {
+		case c == '/':
+			end, err := skipComment(buf, cursor)
+			if err != nil {
+				return nil, err
+			}
+			if end == cursor {
+				return nil, errors.ErrInvalidCharacter(c, "value", cursor)
+			}
+			dst = append(dst, ' ')
+			cursor = end
+		case c == '"':
+			end, err := skipValue(buf, cursor, 0)
+			if err != nil {
+				return nil, err
+			}
+			dst = append(dst, buf[cursor:end]...)
+			expectKey = false
+			cursor = end
+		case c == '\'':
+			quoted, end, err := appendSingleQuotedString(dst, buf, cursor)
+			if err != nil {
+				return nil, err
+			}
+			dst = quoted
+			expectKey = false
+			cursor = end
+		case c == ',':
+			next, err := SkipRelaxedWhiteSpace(buf, cursor+1)
+			if err != nil {
+				return nil, err
+			}
+			if buf[next] != '}' && buf[next] != ']' {
+				// the trailing comma is dropped.
+				dst = append(dst, ',')
+				expectKey = len(nests) > 0 && nests[len(nests)-1] == '{'
+			}
+			cursor++
+		case c == '{' || c == '[':
+			nests = append(nests, c)
+			expectKey = c == '{'
+			dst = append(dst, c)
+			cursor++
+		case c == '}' || c == ']':
+			if len(nests) > 0 {
+				nests = nests[:len(nests)-1]
+			}
+			expectKey = false
+			dst = append(dst, c)
+			cursor++
+		case expectKey && isIdentChar(c):
+			key, end, _ := decodeRelaxedKey(buf, cursor)
+			dst = append(dst, '"')
+			dst = append(dst, key...)
+			dst = append(dst, '"')
+			expectKey = false
+			cursor = end
+		default:
+			dst = append(dst, c)
+			cursor++
+		}
+	}
+	return dst, nil
+}
+
+// skipWhiteSpaceWithOption skips the white spaces, and the comments too if RelaxedOption is set.
+func (s *Stream) skipWhiteSpaceWithOption() (byte, error) {
+	c := s.skipWhiteSpace()
+	if !isRelaxed(s.Option) {
+		return c, nil
+	}
+	for c == '/' {
+		skipped, err := s.skipComment()
+		if err != nil {
+			return 0, err
+		}
+		if !skipped {
+			break
+		}
+		c = s.skipWhiteSpace()
+	}
+	return c, nil
+}
+
+// peekChar returns the character after the cursor, reading the following input if needed.
+func (s *Stream) peekChar() byte {
+	for {
+		c := s.buf[s.cursor+1]
+		if c != nul || !s.read() {
+			return c
+		}
+	}
+}
+
+// readChar returns the character at the cursor, reading the following input if needed.
+// nul is returned at the end of the input.
+func (s *Stream) readChar() byte {
+	for {
+		c := s.char()
+		if c != nul || !s.read() {
+			return c
+		}
+	}
+}
+
+// skipComment skips the comment beginning with '/' at the cursor, and reports whether it's a comment.
+func (s *Stream) skipComment() (bool, error) {
+	start := s.totalOffset()
+	switch s.peekChar() {
+	case '/':
+		for s.cursor += 2; ; s.cursor++ {
+			switch s.readChar() {
+			case '\n':
+				s.cursor++
+				return true, nil
+			case nul:
+				return true, nil
+			}
+		}
+	case '*':
+		for s.cursor += 2; ; s.cursor++ {
+			switch s.readChar() {
+			case '*':
+				if s.peekChar() == '/' {
+					s.cursor += 2
+					return true, nil
+				}
+			case nul:
+				return false, errors.ErrSyntax("json: unterminated comment", start)
+			}
+		}
+	}
+	return false, nil
+}
+
+// skipTrailingComma skips the white spaces and the comments after the comma if RelaxedOption is set,
+// and reports whether the object or array is closed by end after them, which is consumed.
+func (s *Stream) skipTrailingComma(end byte) (bool, error) {
+	if !isRelaxed(s.Option) {
+		return false, nil
+	}
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return false, err
+	}
+	if c != end {
+		return false, nil
+	}
+	s.cursor++
+	return true, nil
+}
+
+// skipValueWithOption skips the value at the cursor, which can be of the relaxed syntax if RelaxedOption is set.
+func (s *Stream) skipValueWithOption(depth int64) error {
+	if !isRelaxed(s.Option) {
+		return s.skipValue(depth)
+	}
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '{', '[':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return errors.ErrExceededMaxDepth(c, s.totalOffset())
+		}
+		s.cursor++
+		return s.skipRelaxedNest(depth)
+	case '\'':
+		_, err := s.appendSingleQuotedString(nil)
+		return err
+	}
+	return s.skipValue(depth)
+}
+
+// skipObjectWithOption skips the rest of the object whose members are being decoded.
+func (s *Stream) skipObjectWithOption(depth int64) error {
+	if !isRelaxed(s.Option) {
+		return s.skipObject(depth)
+	}
+	return s.skipRelaxedNest(depth)
+}
+
+// skipRelaxedNest skips the rest of the object or array of the relaxed syntax after the cursor.
+func (s *Stream) skipRelaxedNest(depth int64) error {
+	nest := 1
+	for {
+		switch c := s.readChar(); c {
+		case '{', '[':
+			nest++
+			depth++
+			if depth > maxDecodeNestingDepth {
+				return errors.ErrExceededMaxDepth(c, s.totalOffset())
+			}
+		case '}', ']':
+			nest--
+			depth--
+			if nest == 0 {
+				s.cursor++
+				return nil
+			}
+		case '"':
+			if err := s.skipValue(depth); err != nil {
+				return err
+			}
+			continue
+		case '\'':
+			if _, err := s.appendSingleQuotedString(nil); err != nil {
+				return err
+			}
+			continue
+		case '/':
+			skipped, err := s.skipComment()
+			if err != nil {
+				return err
+			}
+			if !skipped {
+				return errors.ErrInvalidCharacter(c, "value", s.totalOffset())
+			}
+			continue
+		case nul:
+			return errors.ErrUnexpectedEndOfJSON("value", s.totalOffset())
+		}
+		s.cursor++
+	}
+}
+
+// decodeRelaxedKey decodes the unquoted or single-quoted object key at the cursor.
+// The unquoted key refers to the buffer of the stream, so it must be copied to be kept.
+func (s *Stream) decodeRelaxedKey() ([]byte, error) {
+	if s.char() == '\'' {
+		return s.decodeSingleQuotedString()
+	}
+	start := s.cursor
+	for isIdentChar(s.readChar()) {
+		s.cursor++
+	}
+	return s.buf[start:s.cursor], nil
+}
+
+// quoteRelaxedKey converts the unquoted or single-quoted object key at the cursor into the double-quoted JSON string,
+// which is terminated by nul byte to be decoded by the key decoders.
+func (s *Stream) quoteRelaxedKey() ([]byte, error) {
+	if s.char() == '\'' {
+		quoted, err := s.appendSingleQuotedString(nil)
+		if err != nil {
+			return nil, err
+		}
+		return append(quoted, nul), nil
+	}
+	key, _ := s.decodeRelaxedKey()
+	return append(append(append(make([]byte, 0, len(key)+3), '"'), key...), '"', nul), nil
+}
+
+// decodeSingleQuotedString decodes the single-quoted string at the cursor.
+func (s *Stream) decodeSingleQuotedString() ([]byte, error) {
+	offset := s.totalOffset()
+	quoted, err := s.appendSingleQuotedString(nil)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := unquoteBytes(quoted)
+	if !ok {
+		return nil, errors.ErrSyntax("json: invalid single-quoted string", offset)
+	}
+	return v, nil
+}
+
+// appendSingleQuotedString appends the single-quoted string at the cursor to dst as the double-quoted JSON string.
+func (s *Stream) appendSingleQuotedString(dst []byte) ([]byte, error) {
+	dst = append(dst, '"')
+	for s.cursor++; ; s.cursor++ {
+		switch c := s.readChar(); c {
+		case '\\':
+			s.cursor++
+			switch c := s.readChar(); c {
+			case '\'':
+				dst = append(dst, '\'')
+			case nul:
+				return nil, errors.ErrUnexpectedEndOfJSON("string", s.totalOffset())
+			default:
+				dst = append(dst, '\\', c)
+			}
+		case '"':
+			dst = append(dst, '\\', '"')
+		case '\'':
+			s.cursor++
+			return append(dst, '"'), nil
+		case nul:
+			return nil, errors.ErrUnexpectedEndOfJSON("string", s.totalOffset())
+		default:
+			dst = append(dst, c)
+		}
+	}
+}
+
+type stripState int
+
+const (
+	stripNormal stripState = iota
+	stripString
+	stripLineComment
+	stripBlockComment
+)
+
+// StripComments replaces the comments ( // and /* */ ) in src with the white spaces.
+// The strings are kept as they are, and the newlines in the comments are kept to keep the line numbers.
+func StripComments(src []byte) []byte {
+	dst := make([]byte, len(src))
+	copy(dst, src)
+	var (
+		state = stripNormal
+		quote byte
+	)
+	for i := 0; i < len(dst); i++ {
+		c := dst[i]
+		switch state {
+		case stripNormal:
+			switch c {
+			case '"', '\'':
+				state = stripString
+				quote = c
+			case '/':
+				if i+1 == len(dst) {
+					break
+				}
+				switch dst[i+1] {
+				case '/':
+					state = stripLineComment
+				case '*':
+					state = stripBlockComment
+				default:
+					continue
+				}
+				dst[i], dst[i+1] = ' ', ' '
+				i++
+			}
+		case stripString:
+			switch c {
+			case '\\':
+				i++
+			case quote:
+				state = stripNormal
+			}
+		case stripLineComment:
+			if c == '\n' {
+				state = stripNormal
+				continue
+			}
+			dst[i] = ' '
+		case stripBlockComment:
+			if c == '*' && i+1 < len(dst) && dst[i+1] == '/' {
+				dst[i], dst[i+1] = ' ', ' '
+				i++
+				state = stripNormal
+				continue
+			}
+			if c != '\n' {
+				dst[i] = ' '
+			}
+		}
+	}
+	return dst
+}
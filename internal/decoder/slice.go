@@ -118,7 +118,11 @@ func (d *sliceDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 			return nil
 		case '[':
 			s.cursor++
-			if s.skipWhiteSpace() == ']' {
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == ']' {
 				dst := (*sliceHeader)(p)
 				if dst.data == nil {
 					dst.data = newArray(d.elemType, 0)
@@ -133,7 +137,11 @@ func (d *sliceDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 			srcLen := slice.len
 			capacity := slice.cap
 			data := slice.data
+			relaxed := isRelaxed(s.Option)
 			for {
+				if err := s.checkContext(); err != nil {
+					return err
+				}
 				if capacity <= idx {
 					src := sliceHeader{data: data, len: idx, cap: capacity}
 					capacity *= 2
@@ -156,7 +164,9 @@ func (d *sliceDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 				if err := d.valueDecoder.DecodeStream(s, depth, ep); err != nil {
 					return err
 				}
-				s.skipWhiteSpace()
+				if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+					return err
+				}
 			RETRY:
 				switch s.char() {
 				case ']':
@@ -174,6 +184,19 @@ func (d *sliceDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 					s.cursor++
 					return nil
 				case ',':
+					if relaxed {
+						s.cursor++
+						c, err := s.skipWhiteSpaceWithOption()
+						if err != nil {
+							return err
+						}
+						if c == ']' {
+							// the trailing comma is allowed.
+							goto RETRY
+						}
+						idx++
+						continue
+					}
 					idx++
 				case nul:
 					if s.read() {
@@ -226,8 +249,10 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 			typedmemmove(sliceType, p, nilSlice)
 			return cursor, nil
 		case '[':
-			cursor++
-			cursor = skipWhiteSpace(buf, cursor)
+			cursor, err := skipWhiteSpaceWithOption(ctx, cursor+1)
+			if err != nil {
+				return 0, err
+			}
 			if buf[cursor] == ']' {
 				dst := (*sliceHeader)(p)
 				if dst.data == nil {
@@ -243,7 +268,13 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 			srcLen := slice.len
 			capacity := slice.cap
 			data := slice.data
+			relaxed := isRelaxed(ctx.Option)
 			for {
+				if relaxed {
+					if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+						return 0, err
+					}
+				}
 				if capacity <= idx {
 					src := sliceHeader{data: data, len: idx, cap: capacity}
 					capacity *= 2
@@ -265,8 +296,15 @@ func (d *sliceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 				if err != nil {
 					return 0, err
 				}
-				cursor = c
-				cursor = skipWhiteSpace(buf, cursor)
+				cursor, err = skipWhiteSpaceWithOption(ctx, c)
+				if err != nil {
+					return 0, err
+				}
+				if relaxed {
+					if cursor, err = skipTrailingComma(buf, cursor, ']'); err != nil {
+						return 0, err
+					}
+				}
 				switch buf[cursor] {
 				case ']':
 					slice.cap = capacity
@@ -333,6 +371,7 @@ func (d *sliceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][
 					return nil, 0, err
 				}
 				if found {
+					ctx.Option.pushPathIndex(idx)
 					if child != nil {
 						oldPath := ctx.Option.Path.node
 						ctx.Option.Path.node = child
@@ -350,8 +389,10 @@ func (d *sliceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][
 							return nil, 0, err
 						}
 						ret = append(ret, buf[start:end])
+						ctx.Option.addPathMatch(start, end)
 						cursor = end
 					}
+					ctx.Option.popPathLocation()
 				} else {
 					c, err := skipValue(buf, cursor, depth)
 					if err != nil {
@@ -2,6 +2,7 @@ package decoder
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"strconv"
@@ -12,20 +13,33 @@ import (
 
 const (
 	initBufSize = 512
+
+	// contextCheckInterval is the number of the decoded elements between checking the context.
+	contextCheckInterval = 64
 )
 
 type Stream struct {
-	buf                   []byte
-	bufSize               int64
-	length                int64
-	r                     io.Reader
-	offset                int64
-	cursor                int64
-	filledBuffer          bool
-	allRead               bool
-	UseNumber             bool
-	DisallowUnknownFields bool
-	Option                *Option
+	buf          []byte
+	bufSize      int64
+	length       int64
+	r            io.Reader
+	offset       int64
+	cursor       int64
+	filledBuffer bool
+	allRead      bool
+	Option       *Option
+
+	// delims holds the delimiters beginning the objects and arrays read by the token reader.
+	delims []byte
+
+	// discardOnRead allows read to drop the bytes before cursor instead of keeping them in the buffer.
+	// It must only be set while nothing refers to the consumed part of the buffer.
+	discardOnRead bool
+
+	// contextCount counts the elements decoded since the context was checked last,
+	// and ctxErr holds the error when reading is aborted by the context.
+	contextCount int
+	ctxErr       error
 }
 
 func NewStream(r io.Reader) *Stream {
@@ -37,6 +51,51 @@ func NewStream(r io.Reader) *Stream {
 	}
 }
 
+// SetContext sets the context to abort reading and decoding the stream when it's done.
+// The context is also passed to the UnmarshalJSON implementations taking context.Context.
+func (s *Stream) SetContext(ctx context.Context) {
+	s.Option.Flags |= ContextOption
+	s.Option.Context = ctx
+	s.ctxErr = nil
+}
+
+// ContextError returns the error wrapping the error of the context instead of err
+// if reading the stream was aborted because the context is done.
+func (s *Stream) ContextError(err error) error {
+	if err != nil && s.ctxErr != nil {
+		return s.ctxErr
+	}
+	return err
+}
+
+// checkContext checks the context every contextCheckInterval calls,
+// so that decoding the values already in the buffer can also be aborted.
+func (s *Stream) checkContext() error {
+	if s.Option.Flags&ContextOption == 0 {
+		return nil
+	}
+	s.contextCount++
+	if s.contextCount < contextCheckInterval {
+		return nil
+	}
+	s.contextCount = 0
+	return s.contextDone()
+}
+
+func (s *Stream) contextDone() error {
+	ctx := s.Option.Context
+	if s.Option.Flags&ContextOption == 0 || ctx == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		s.ctxErr = errors.ErrCanceled(ctx.Err(), s.totalOffset())
+		return s.ctxErr
+	default:
+		return nil
+	}
+}
+
 func (s *Stream) TotalOffset() int64 {
 	return s.totalOffset()
 }
@@ -57,6 +116,17 @@ func (s *Stream) PrepareForDecode() error {
 		case ' ', '\t', '\r', '\n':
 			s.cursor++
 			continue
+		case '/':
+			if !isRelaxed(s.Option) {
+				break
+			}
+			skipped, err := s.skipComment()
+			if err != nil {
+				return err
+			}
+			if skipped {
+				continue
+			}
 		case ',', ':':
 			s.cursor++
 			return nil
@@ -139,7 +209,7 @@ func (s *Stream) Token() (interface{}, error) {
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			bytes := floatBytes(s)
 			str := *(*string)(unsafe.Pointer(&bytes))
-			if s.UseNumber {
+			if s.Option.Flags&UseNumberOption != 0 {
 				return json.Number(str), nil
 			}
 			f64, err := strconv.ParseFloat(str, 64)
@@ -211,6 +281,12 @@ func (s *Stream) read() bool {
 	if s.allRead {
 		return false
 	}
+	if s.contextDone() != nil {
+		return false
+	}
+	if s.discardOnRead {
+		s.discardConsumed()
+	}
 	buf := s.readBuf()
 	last := len(buf) - 1
 	buf[last] = nul
@@ -229,6 +305,22 @@ func (s *Stream) read() bool {
 	return true
 }
 
+// discardConsumed moves the unread bytes to the head of the buffer,
+// so that the buffer doesn't grow while a large input is being skipped.
+func (s *Stream) discardConsumed() {
+	if s.cursor == 0 {
+		return
+	}
+	n := int64(copy(s.buf, s.buf[s.cursor:s.length]))
+	for i := n; i < s.length; i++ {
+		s.buf[i] = nul
+	}
+	s.offset += s.cursor
+	s.length = n
+	s.cursor = 0
+	s.filledBuffer = false
+}
+
 func (s *Stream) skipWhiteSpace() byte {
 	p := s.bufptr()
 LOOP:
@@ -456,6 +548,21 @@ func (s *Stream) skipValue(depth int64) error {
 	}
 }
 
+// SkipValue skips the next value in the stream without allocating.
+// The bytes consumed by the value are dropped from the buffer while reading,
+// so the buffer doesn't grow with the size of the value.
+func (s *Stream) SkipValue(depth int64) error {
+	if s.skipWhiteSpace() == nul {
+		return errors.ErrUnexpectedEndOfJSON("value", s.totalOffset())
+	}
+	discardOnRead := s.discardOnRead
+	defer func() {
+		s.discardOnRead = discardOnRead
+	}()
+	s.discardOnRead = true
+	return s.skipValueWithOption(depth)
+}
+
 func nullBytes(s *Stream) error {
 	// current cursor's character is 'n'
 	s.cursor++
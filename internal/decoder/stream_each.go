@@ -0,0 +1,171 @@
+package decoder
+
+import (
+	"io"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// Depth returns the number of the arrays and objects the stream is reading.
+func (s *Stream) Depth() int64 {
+	return int64(len(s.delims))
+}
+
+// DecodeArrayEach reads the next array from the stream and calls fn for each element.
+// The stream is positioned at the beginning of the element when fn is called,
+// and the element is skipped if fn doesn't read it. null is read as the empty array.
+func (s *Stream) DecodeArrayEach(fn func(idx int) error) error {
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '[':
+	case 'n':
+		return nullBytes(s)
+	case nul:
+		return io.EOF
+	default:
+		return errors.ErrExpected("array", s.totalOffset())
+	}
+	if err := s.enterEach(); err != nil {
+		return err
+	}
+	defer s.leaveEach()
+	if c, err = s.skipWhiteSpaceWithOption(); err != nil {
+		return err
+	}
+	if c == ']' {
+		s.cursor++
+		return nil
+	}
+	for idx := 0; ; idx++ {
+		if err := s.callEach(func() error { return fn(idx) }); err != nil {
+			return err
+		}
+		if c, err = s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
+		switch c {
+		case ']':
+			s.cursor++
+			return nil
+		case ',':
+			s.cursor++
+		default:
+			return errors.ErrInvalidCharacter(s.char(), "slice", s.totalOffset())
+		}
+		if closed, err := s.skipTrailingComma(']'); err != nil || closed {
+			return err
+		}
+	}
+}
+
+// DecodeObjectEach reads the next object from the stream and calls fn for each member.
+// The stream is positioned at the beginning of the value when fn is called,
+// and the value is skipped if fn doesn't read it. null is read as the empty object.
+func (s *Stream) DecodeObjectEach(fn func(key string) error) error {
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '{':
+	case 'n':
+		return nullBytes(s)
+	case nul:
+		return io.EOF
+	default:
+		return errors.ErrExpected("object", s.totalOffset())
+	}
+	if err := s.enterEach(); err != nil {
+		return err
+	}
+	defer s.leaveEach()
+	if c, err = s.skipWhiteSpaceWithOption(); err != nil {
+		return err
+	}
+	if c == '}' {
+		s.cursor++
+		return nil
+	}
+	relaxed := isRelaxed(s.Option)
+	for {
+		var k []byte
+		switch c := s.skipWhiteSpace(); {
+		case relaxed && isRelaxedKeyStart(c):
+			k, err = s.decodeRelaxedKey()
+		case c == '"':
+			k, err = stringBytes(s)
+		default:
+			return errors.ErrExpected("object key", s.totalOffset())
+		}
+		if err != nil {
+			return err
+		}
+		key := string(k)
+		if c, err = s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
+		if c != ':' {
+			return errors.ErrExpected("colon after object key", s.totalOffset())
+		}
+		s.cursor++
+		if err := s.callEach(func() error { return fn(key) }); err != nil {
+			return err
+		}
+		if c, err = s.skipWhiteSpaceWithOption(); err != nil {
+			return err
+		}
+		switch c {
+		case '}':
+			s.cursor++
+			return nil
+		case ',':
+			s.cursor++
+		default:
+			return errors.ErrExpected("comma after object value", s.totalOffset())
+		}
+		if closed, err := s.skipTrailingComma('}'); err != nil || closed {
+			return err
+		}
+	}
+}
+
+func (s *Stream) enterEach() error {
+	if err := s.pushDelim(s.char()); err != nil {
+		return err
+	}
+	s.cursor++
+	return nil
+}
+
+func (s *Stream) leaveEach() {
+	s.delims = s.delims[:len(s.delims)-1]
+}
+
+func (s *Stream) callEach(fn func() error) error {
+	if err := s.checkContext(); err != nil {
+		return err
+	}
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case nul:
+		return errors.ErrUnexpectedEndOfJSON("value", s.totalOffset())
+	case ',', ']', '}', ':':
+		return errors.ErrInvalidBeginningOfValue(s.char(), s.totalOffset())
+	}
+	// drop the consumed bytes like Decode, so that the buffer doesn't grow with the number of elements.
+	s.Reset()
+	offset := s.totalOffset()
+	if err := fn(); err != nil {
+		return err
+	}
+	if s.totalOffset() == offset {
+		return s.skipValueWithOption(s.Depth())
+	}
+	return nil
+}
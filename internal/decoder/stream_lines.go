@@ -0,0 +1,40 @@
+package decoder
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReadLine reads the next line from the stream and returns it without the line terminator ("\n" or "\r\n").
+// The last line may not be terminated. io.EOF is returned when no byte is left.
+// The bytes refer to the buffer of the stream, so they are valid until the next read from the stream.
+func (s *Stream) ReadLine() ([]byte, error) {
+	// drop the previous lines, so that the buffer doesn't grow with the number of lines.
+	s.Reset()
+	start := s.cursor
+	for {
+		if idx := bytes.IndexByte(s.buf[s.cursor:s.length], '\n'); idx >= 0 {
+			end := s.cursor + int64(idx)
+			s.cursor = end + 1
+			return trimCR(s.buf[start:end]), nil
+		}
+		s.cursor = s.length
+		if s.read() {
+			continue
+		}
+		if err := s.ContextError(io.EOF); err != io.EOF {
+			return nil, err
+		}
+		if s.cursor == start {
+			return nil, io.EOF
+		}
+		return trimCR(s.buf[start:s.cursor]), nil
+	}
+}
+
+func trimCR(line []byte) []byte {
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		return line[:len(line)-1]
+	}
+	return line
+}
@@ -0,0 +1,44 @@
+package decoder
+
+import (
+	"bytes"
+	"io"
+)
+
+// RecordSeparator is the RS character beginning each record of JSON text sequences (RFC 7464).
+const RecordSeparator = 0x1E
+
+// ReadRecord reads the next record of JSON text sequences from the stream and returns it without RS.
+// The bytes before the first RS are ignored, and the record ends at the next RS or the end of the input,
+// so a truncated record never affects the following ones. io.EOF is returned when no record is left.
+// The bytes refer to the buffer of the stream, so they are valid until the next read from the stream.
+func (s *Stream) ReadRecord() ([]byte, error) {
+	// drop the previous records, so that the buffer doesn't grow with the number of records.
+	s.Reset()
+	for {
+		if idx := bytes.IndexByte(s.buf[s.cursor:s.length], RecordSeparator); idx >= 0 {
+			s.cursor += int64(idx) + 1
+			break
+		}
+		s.cursor = s.length
+		if s.read() {
+			continue
+		}
+		return nil, s.ContextError(io.EOF)
+	}
+	start := s.cursor
+	for {
+		if idx := bytes.IndexByte(s.buf[s.cursor:s.length], RecordSeparator); idx >= 0 {
+			s.cursor += int64(idx)
+			return s.buf[start:s.cursor], nil
+		}
+		s.cursor = s.length
+		if s.read() {
+			continue
+		}
+		if err := s.ContextError(io.EOF); err != io.EOF {
+			return nil, err
+		}
+		return s.buf[start:s.cursor], nil
+	}
+}
@@ -0,0 +1,240 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// Kind is the kind of the next token in the stream.
+type Kind byte
+
+const (
+	InvalidKind     Kind = 0
+	NullKind        Kind = 'n'
+	BoolKind        Kind = 'b'
+	NumberKind      Kind = '0'
+	StringKind      Kind = '"'
+	ObjectStartKind Kind = '{'
+	ObjectEndKind   Kind = '}'
+	ArrayStartKind  Kind = '['
+	ArrayEndKind    Kind = ']'
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NullKind:
+		return "null"
+	case BoolKind:
+		return "bool"
+	case NumberKind:
+		return "number"
+	case StringKind:
+		return "string"
+	case ObjectStartKind:
+		return "object start"
+	case ObjectEndKind:
+		return "object end"
+	case ArrayStartKind:
+		return "array start"
+	case ArrayEndKind:
+		return "array end"
+	}
+	return "invalid"
+}
+
+// PeekKind returns the kind of the next token without reading it.
+// The separators ',' and ':' are skipped in the same way as Token.
+// InvalidKind is returned at the end of the input or if the next character can't begin a token.
+func (s *Stream) PeekKind() Kind {
+	switch s.skipSeparator() {
+	case 'n':
+		return NullKind
+	case 't', 'f':
+		return BoolKind
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return NumberKind
+	case '"':
+		return StringKind
+	case '{':
+		return ObjectStartKind
+	case '}':
+		return ObjectEndKind
+	case '[':
+		return ArrayStartKind
+	case ']':
+		return ArrayEndKind
+	}
+	return InvalidKind
+}
+
+// ReadString reads the next string token and returns its unescaped bytes.
+// The bytes refer to the buffer of the stream, so they are valid until the next read from the stream.
+func (s *Stream) ReadString() ([]byte, error) {
+	if err := s.expectKind(StringKind); err != nil {
+		return nil, err
+	}
+	return stringBytes(s)
+}
+
+// ReadNumber reads the next number token and returns its literal bytes.
+// The bytes refer to the buffer of the stream, so they are valid until the next read from the stream.
+func (s *Stream) ReadNumber() ([]byte, error) {
+	if err := s.expectKind(NumberKind); err != nil {
+		return nil, err
+	}
+	start := s.totalOffset()
+	bytes := floatBytes(s)
+	if !validNumber(bytes) {
+		return nil, errors.ErrSyntax(fmt.Sprintf("invalid number literal %q", bytes), start)
+	}
+	return bytes, nil
+}
+
+// validNumber reports whether b is a number literal of the JSON grammar.
+// The range isn't checked, so that the literal can be parsed by the caller as needed.
+func validNumber(b []byte) bool {
+	if len(b) > 0 && b[0] == '-' {
+		b = b[1:]
+	}
+	switch {
+	case len(b) == 0:
+		return false
+	case b[0] == '0':
+		b = b[1:]
+	case '1' <= b[0] && b[0] <= '9':
+		b = skipDigits(b[1:])
+	default:
+		return false
+	}
+	if len(b) > 0 && b[0] == '.' {
+		digits := skipDigits(b[1:])
+		if len(digits) == len(b)-1 {
+			return false
+		}
+		b = digits
+	}
+	if len(b) > 0 && (b[0] == 'e' || b[0] == 'E') {
+		b = b[1:]
+		if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+			b = b[1:]
+		}
+		digits := skipDigits(b)
+		if len(digits) == len(b) {
+			return false
+		}
+		b = digits
+	}
+	return len(b) == 0
+}
+
+func skipDigits(b []byte) []byte {
+	for len(b) > 0 && '0' <= b[0] && b[0] <= '9' {
+		b = b[1:]
+	}
+	return b
+}
+
+// ReadBool reads the next true or false token.
+func (s *Stream) ReadBool() (bool, error) {
+	if err := s.expectKind(BoolKind); err != nil {
+		return false, err
+	}
+	if s.char() == 't' {
+		if err := trueBytes(s); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err := falseBytes(s); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// ReadNull reads the next null token.
+func (s *Stream) ReadNull() error {
+	if err := s.expectKind(NullKind); err != nil {
+		return err
+	}
+	return nullBytes(s)
+}
+
+// ReadDelim reads the next delimiter token, one of '{', '}', '[' or ']', and updates the depth.
+// The delimiter ending an object or an array must match the one beginning it.
+func (s *Stream) ReadDelim() (byte, error) {
+	kind := s.PeekKind()
+	switch kind {
+	case ObjectStartKind, ArrayStartKind:
+		if err := s.pushDelim(byte(kind)); err != nil {
+			return 0, err
+		}
+	case ObjectEndKind, ArrayEndKind:
+		if err := s.popDelim(byte(kind)); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, s.kindError(kind, "delimiter")
+	}
+	s.cursor++
+	return byte(kind), nil
+}
+
+// pushDelim records the delimiter beginning an object or an array.
+func (s *Stream) pushDelim(c byte) error {
+	if int64(len(s.delims)) >= maxDecodeNestingDepth {
+		return errors.ErrExceededMaxDepth(s.char(), s.totalOffset())
+	}
+	s.delims = append(s.delims, c)
+	return nil
+}
+
+// popDelim removes the delimiter beginning the object or the array ended by c.
+func (s *Stream) popDelim(c byte) error {
+	n := len(s.delims)
+	if n == 0 || (c == '}') != (s.delims[n-1] == '{') {
+		return errors.ErrInvalidCharacter(s.char(), "delimiter", s.totalOffset())
+	}
+	s.delims = s.delims[:n-1]
+	return nil
+}
+
+func (s *Stream) expectKind(expected Kind) error {
+	if kind := s.PeekKind(); kind != expected {
+		return s.kindError(kind, expected.String())
+	}
+	return nil
+}
+
+func (s *Stream) kindError(kind Kind, expected string) error {
+	if s.ctxErr != nil {
+		return s.ctxErr
+	}
+	if kind != InvalidKind {
+		return errors.ErrExpected(expected, s.totalOffset())
+	}
+	if s.char() == nul {
+		return io.EOF
+	}
+	return errors.ErrInvalidCharacter(s.char(), expected, s.totalOffset())
+}
+
+// skipSeparator skips the white spaces and the separators between tokens, and returns the next character.
+// No bytes before the next token are referred, so they are dropped from the buffer while reading
+// to keep the buffer from growing with the number of tokens.
+func (s *Stream) skipSeparator() byte {
+	discardOnRead := s.discardOnRead
+	defer func() {
+		s.discardOnRead = discardOnRead
+	}()
+	s.discardOnRead = true
+	for {
+		switch c := s.skipWhiteSpace(); c {
+		case ',', ':':
+			s.cursor++
+		default:
+			return c
+		}
+	}
+}
@@ -35,6 +35,14 @@ func (d *stringDecoder) errUnmarshalType(typeName string, offset int64) *errors.
 }
 
 func (d *stringDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	if isRelaxed(s.Option) && s.skipWhiteSpace() == '\'' {
+		bytes, err := s.decodeSingleQuotedString()
+		if err != nil {
+			return err
+		}
+		**(**string)(unsafe.Pointer(&p)) = string(bytes)
+		return nil
+	}
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
 		return err
@@ -48,6 +56,17 @@ func (d *stringDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 }
 
 func (d *stringDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	if isRelaxed(ctx.Option) {
+		cursor = skipWhiteSpace(ctx.Buf, cursor)
+		if ctx.Buf[cursor] == '\'' {
+			bytes, c, err := decodeSingleQuotedString(ctx.Buf, cursor)
+			if err != nil {
+				return 0, err
+			}
+			**(**string)(unsafe.Pointer(&p)) = string(bytes)
+			return c, nil
+		}
+	}
 	bytes, c, err := d.decodeByte(ctx.Buf, cursor)
 	if err != nil {
 		return 0, err
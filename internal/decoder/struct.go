@@ -383,6 +383,15 @@ func decodeKey(d *structDecoder, buf []byte, cursor int64) (int64, *structFieldS
 	return cursor, field, nil
 }
 
+// fieldByKey looks up the field by the unquoted or single-quoted key of the relaxed syntax,
+// ignoring the case like the optimized key decoders.
+func (d *structDecoder) fieldByKey(key []byte) *structFieldSet {
+	if field, exists := d.fieldMap[string(key)]; exists {
+		return field
+	}
+	return d.fieldMap[toASCIILower(string(key))]
+}
+
 func decodeKeyByBitmapUint8Stream(d *structDecoder, s *Stream) (*structFieldSet, string, error) {
 	var (
 		curBit uint8 = math.MaxUint8
@@ -660,6 +669,16 @@ func decodeKeyStream(d *structDecoder, s *Stream) (*structFieldSet, string, erro
 	return d.fieldMap[k], k, nil
 }
 
+// decodeRelaxedKeyStream decodes the unquoted or single-quoted key of the relaxed syntax like keyStreamDecoder.
+func (d *structDecoder) decodeRelaxedKeyStream(s *Stream) (*structFieldSet, string, error) {
+	key, err := s.decodeRelaxedKey()
+	if err != nil {
+		return nil, "", err
+	}
+	k := *(*string)(unsafe.Pointer(&key))
+	return d.fieldByKey(key), k, nil
+}
+
 func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
 	depth++
 	if depth > maxDecodeNestingDepth {
@@ -679,7 +698,11 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 		}
 	}
 	s.cursor++
-	if s.skipWhiteSpace() == '}' {
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	if c == '}' {
 		s.cursor++
 		return nil
 	}
@@ -691,23 +714,45 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 	if firstWin {
 		seenFields = make(map[int]struct{}, d.fieldUniqueNameNum)
 	}
+	relaxed := isRelaxed(s.Option)
 	for {
+		if err := s.checkContext(); err != nil {
+			return err
+		}
 		s.reset()
-		field, key, err := d.keyStreamDecoder(d, s)
+		var (
+			field *structFieldSet
+			key   string
+			err   error
+		)
+		if relaxed && isRelaxedKeyStart(s.char()) {
+			field, key, err = d.decodeRelaxedKeyStream(s)
+		} else {
+			field, key, err = d.keyStreamDecoder(d, s)
+		}
+		if err != nil {
+			return err
+		}
+		c, err := s.skipWhiteSpaceWithOption()
 		if err != nil {
 			return err
 		}
-		if s.skipWhiteSpace() != ':' {
+		if c != ':' {
 			return errors.ErrExpected("colon after object key", s.totalOffset())
 		}
 		s.cursor++
+		if relaxed {
+			if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+				return err
+			}
+		}
 		if field != nil {
 			if field.err != nil {
 				return field.err
 			}
 			if firstWin {
 				if _, exists := seenFields[field.fieldIdx]; exists {
-					if err := s.skipValue(depth); err != nil {
+					if err := s.skipValueWithOption(depth); err != nil {
 						return err
 					}
 				} else {
@@ -716,7 +761,7 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 					}
 					seenFieldNum++
 					if d.fieldUniqueNameNum <= seenFieldNum {
-						return s.skipObject(depth)
+						return s.skipObjectWithOption(depth)
 					}
 					seenFields[field.fieldIdx] = struct{}{}
 				}
@@ -725,14 +770,17 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 					return err
 				}
 			}
-		} else if s.DisallowUnknownFields {
+		} else if s.Option.Flags&DisallowUnknownFieldsOption != 0 {
 			return fmt.Errorf("json: unknown field %q", key)
 		} else {
-			if err := s.skipValue(depth); err != nil {
+			if err := s.skipValueWithOption(depth); err != nil {
 				return err
 			}
 		}
-		c := s.skipWhiteSpace()
+		c, err = s.skipWhiteSpaceWithOption()
+		if err != nil {
+			return err
+		}
 		if c == '}' {
 			s.cursor++
 			return nil
@@ -741,6 +789,17 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 			return errors.ErrExpected("comma after object element", s.totalOffset())
 		}
 		s.cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == '}' {
+				s.cursor++
+				return nil
+			}
+		}
 	}
 }
 
@@ -765,7 +824,10 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 		return 0, errors.ErrInvalidBeginningOfValue(char(b, cursor), cursor)
 	}
 	cursor++
-	cursor = skipWhiteSpace(buf, cursor)
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
 	if buf[cursor] == '}' {
 		cursor++
 		return cursor, nil
@@ -778,12 +840,37 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 	if firstWin {
 		seenFields = make(map[int]struct{}, d.fieldUniqueNameNum)
 	}
+	disallowUnknownFields := (ctx.Option.Flags & DisallowUnknownFieldsOption) != 0
+	relaxed := isRelaxed(ctx.Option)
 	for {
-		c, field, err := d.keyDecoder(d, buf, cursor)
+		var (
+			c     int64
+			field *structFieldSet
+			key   []byte
+			err   error
+		)
+		if relaxed && isRelaxedKeyStart(buf[cursor]) {
+			key, c, err = decodeRelaxedKey(buf, cursor)
+			field = d.fieldByKey(key)
+		} else {
+			c, field, err = d.keyDecoder(d, buf, cursor)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if field == nil && disallowUnknownFields {
+			if key == nil {
+				key = buf[skipWhiteSpace(buf, cursor):c]
+				if k, ok := unquoteBytes(key); ok {
+					key = k
+				}
+			}
+			return 0, fmt.Errorf("json: unknown field %q", key)
+		}
+		cursor, err = skipWhiteSpaceWithOption(ctx, c)
 		if err != nil {
 			return 0, err
 		}
-		cursor = skipWhiteSpace(buf, c)
 		if char(b, cursor) != ':' {
 			return 0, errors.ErrExpected("colon after object key", cursor)
 		}
@@ -791,13 +878,18 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 		if cursor >= buflen {
 			return 0, errors.ErrExpected("object value after colon", cursor)
 		}
+		if relaxed {
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+		}
 		if field != nil {
 			if field.err != nil {
 				return 0, field.err
 			}
 			if firstWin {
 				if _, exists := seenFields[field.fieldIdx]; exists {
-					c, err := skipValue(buf, cursor, depth)
+					c, err := skipValueWithOption(ctx, cursor, depth)
 					if err != nil {
 						return 0, err
 					}
@@ -810,7 +902,7 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 					cursor = c
 					seenFieldNum++
 					if d.fieldUniqueNameNum <= seenFieldNum {
-						return skipObject(buf, cursor, depth)
+						return skipObjectWithOption(ctx, cursor, depth)
 					}
 					seenFields[field.fieldIdx] = struct{}{}
 				}
@@ -822,13 +914,16 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 				cursor = c
 			}
 		} else {
-			c, err := skipValue(buf, cursor, depth)
+			c, err := skipValueWithOption(ctx, cursor, depth)
 			if err != nil {
 				return 0, err
 			}
 			cursor = c
 		}
-		cursor = skipWhiteSpace(buf, cursor)
+		cursor, err = skipWhiteSpaceWithOption(ctx, cursor)
+		if err != nil {
+			return 0, err
+		}
 		if char(b, cursor) == '}' {
 			cursor++
 			return cursor, nil
@@ -837,6 +932,15 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 			return 0, errors.ErrExpected("comma after object element", cursor)
 		}
 		cursor++
+		if relaxed {
+			// the trailing comma is allowed.
+			if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+				return 0, err
+			}
+			if buf[cursor] == '}' {
+				return cursor + 1, nil
+			}
+		}
 	}
 }
 
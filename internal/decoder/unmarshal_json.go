@@ -35,14 +35,17 @@ func (d *unmarshalJSONDecoder) annotateError(cursor int64, err error) {
 }
 
 func (d *unmarshalJSONDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
-	s.skipWhiteSpace()
+	if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+		return err
+	}
 	start := s.cursor
-	if err := s.skipValue(depth); err != nil {
+	if err := s.skipValueWithOption(depth); err != nil {
+		return err
+	}
+	dst, err := copyValue(s.buf[start:s.cursor], s.Option)
+	if err != nil {
 		return err
 	}
-	src := s.buf[start:s.cursor]
-	dst := make([]byte, len(src))
-	copy(dst, src)
 
 	v := *(*interface{})(unsafe.Pointer(&emptyInterface{
 		typ: d.typ,
@@ -71,15 +74,19 @@ func (d *unmarshalJSONDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Poi
 
 func (d *unmarshalJSONDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	buf := ctx.Buf
-	cursor = skipWhiteSpace(buf, cursor)
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
 	start := cursor
-	end, err := skipValue(buf, cursor, depth)
+	end, err := skipValueWithOption(ctx, cursor, depth)
+	if err != nil {
+		return 0, err
+	}
+	dst, err := copyValue(buf[start:end], ctx.Option)
 	if err != nil {
 		return 0, err
 	}
-	src := buf[start:end]
-	dst := make([]byte, len(src))
-	copy(dst, src)
 
 	v := *(*interface{})(unsafe.Pointer(&emptyInterface{
 		typ: d.typ,
@@ -99,6 +106,17 @@ func (d *unmarshalJSONDecoder) Decode(ctx *RuntimeContext, cursor, depth int64,
 	return end, nil
 }
 
+// copyValue copies src to be passed to UnmarshalJSON.
+// The value of the relaxed syntax is converted into JSON, since the unmarshalers expect JSON.
+func copyValue(src []byte, opt *Option) ([]byte, error) {
+	if isRelaxed(opt) {
+		return relaxedToJSON(src)
+	}
+	dst := make([]byte, len(src))
+	copy(dst, src)
+	return dst, nil
+}
+
 func (d *unmarshalJSONDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
 	return nil, 0, fmt.Errorf("json: unmarshal json decoder does not support decode path")
 }
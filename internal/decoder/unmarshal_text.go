@@ -42,9 +42,11 @@ var (
 )
 
 func (d *unmarshalTextDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
-	s.skipWhiteSpace()
+	if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+		return err
+	}
 	start := s.cursor
-	if err := s.skipValue(depth); err != nil {
+	if err := s.skipValueWithOption(depth); err != nil {
 		return err
 	}
 	src := s.buf[start:s.cursor]
@@ -75,9 +77,10 @@ func (d *unmarshalTextDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Poi
 			}
 		}
 	}
-	dst := make([]byte, len(src))
-	copy(dst, src)
-
+	dst, err := copyValue(src, s.Option)
+	if err != nil {
+		return err
+	}
 	if b, ok := unquoteBytes(dst); ok {
 		dst = b
 	}
@@ -94,9 +97,12 @@ func (d *unmarshalTextDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Poi
 
 func (d *unmarshalTextDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	buf := ctx.Buf
-	cursor = skipWhiteSpace(buf, cursor)
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
 	start := cursor
-	end, err := skipValue(buf, cursor, depth)
+	end, err := skipValueWithOption(ctx, cursor, depth)
 	if err != nil {
 		return 0, err
 	}
@@ -128,7 +134,12 @@ func (d *unmarshalTextDecoder) Decode(ctx *RuntimeContext, cursor, depth int64,
 			}
 		}
 	}
-
+	if src[0] == '\'' {
+		// the single-quoted string of the relaxed syntax.
+		if src, err = relaxedToJSON(src); err != nil {
+			return 0, err
+		}
+	}
 	if s, ok := unquoteBytes(src); ok {
 		src = s
 	}
@@ -0,0 +1,1019 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// ValueKind is the kind of Value.
+type ValueKind uint8
+
+const (
+	// InvalidValue is the kind of the value that doesn't exist, such as the result of Get with the missing key.
+	InvalidValue ValueKind = iota
+	NullValue
+	BoolValue
+	NumberValue
+	StringValue
+	ArrayValue
+	ObjectValue
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case NullValue:
+		return "null"
+	case BoolValue:
+		return "bool"
+	case NumberValue:
+		return "number"
+	case StringValue:
+		return "string"
+	case ArrayValue:
+		return "array"
+	case ObjectValue:
+		return "object"
+	}
+	return "invalid"
+}
+
+// Value is a mutable JSON value.
+// The zero Value is null, and the nil *Value represents the value that doesn't exist.
+// All the methods can be called with the nil *Value, so the lookup by Get can be chained.
+type Value struct {
+	kind    ValueKind
+	boolean bool
+	// str is the string, or the literal of the number to keep its precision.
+	str     string
+	elems   []*Value
+	members []valueMember
+}
+
+type valueMember struct {
+	key   string
+	value *Value
+}
+
+// NewObject returns a new empty object.
+func NewObject() *Value {
+	return &Value{kind: ObjectValue, members: []valueMember{}}
+}
+
+// NewArray returns a new empty array.
+func NewArray() *Value {
+	return &Value{kind: ArrayValue, elems: []*Value{}}
+}
+
+// ValueOf converts v into Value.
+// *Value is returned as it is, and the other values are converted through their JSON encoding.
+func ValueOf(v interface{}) (*Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return &Value{kind: NullValue}, nil
+	case *Value:
+		if v == nil {
+			return &Value{kind: NullValue}, nil
+		}
+		return v, nil
+	case Value:
+		return &v, nil
+	case bool:
+		return &Value{kind: BoolValue, boolean: v}, nil
+	case string:
+		return &Value{kind: StringValue, str: v}, nil
+	case int:
+		return &Value{kind: NumberValue, str: strconv.Itoa(v)}, nil
+	case int64:
+		return &Value{kind: NumberValue, str: strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return &Value{kind: NumberValue, str: strconv.FormatUint(v, 10)}, nil
+	}
+	b, err := encoder.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return ParseValue(b)
+}
+
+// ParseValue decodes data into Value.
+func ParseValue(data []byte) (*Value, error) {
+	src := make([]byte, len(data)+1) // append nul byte to the end
+	copy(src, data)
+	v := &Value{}
+	cursor, err := valueDec.decode(&RuntimeContext{Buf: src, Option: &Option{}}, 0, 0, v)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEndOfValue(src, cursor); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Kind returns the kind of the value.
+func (v *Value) Kind() ValueKind {
+	if v == nil {
+		return InvalidValue
+	}
+	if v.kind == InvalidValue {
+		return NullValue
+	}
+	return v.kind
+}
+
+// Exists reports whether the value exists.
+func (v *Value) Exists() bool {
+	return v != nil
+}
+
+// IsNull reports whether the value is null.
+func (v *Value) IsNull() bool {
+	return v.Kind() == NullValue
+}
+
+// Get returns the value at the path, which consists of the object keys (string) and the array indexes (int).
+// nil is returned if the value doesn't exist.
+func (v *Value) Get(path ...interface{}) *Value {
+	for _, sel := range path {
+		switch sel := sel.(type) {
+		case string:
+			v = v.member(sel)
+		case int:
+			v = v.elem(sel)
+		default:
+			return nil
+		}
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
+
+func (v *Value) member(key string) *Value {
+	if v.Kind() != ObjectValue {
+		return nil
+	}
+	if i := v.memberIndex(key); i >= 0 {
+		return v.members[i].value
+	}
+	return nil
+}
+
+func (v *Value) memberIndex(key string) int {
+	for i, m := range v.members {
+		if m.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (v *Value) elem(i int) *Value {
+	if v.Kind() != ArrayValue || i < 0 || i >= len(v.elems) {
+		return nil
+	}
+	return v.elems[i]
+}
+
+// Bool returns the boolean value.
+func (v *Value) Bool() (bool, error) {
+	if v.Kind() != BoolValue {
+		return false, v.errType(reflect.TypeOf(false))
+	}
+	return v.boolean, nil
+}
+
+// Int returns the number as int64. An error is returned if the number isn't an integer or overflows int64.
+func (v *Value) Int() (int64, error) {
+	if v.Kind() != NumberValue {
+		return 0, v.errType(reflect.TypeOf(int64(0)))
+	}
+	n, err := strconv.ParseInt(v.str, 10, 64)
+	if err != nil {
+		return 0, v.errType(reflect.TypeOf(int64(0)))
+	}
+	return n, nil
+}
+
+// Uint returns the number as uint64. An error is returned if the number isn't a non-negative integer or overflows uint64.
+func (v *Value) Uint() (uint64, error) {
+	if v.Kind() != NumberValue {
+		return 0, v.errType(reflect.TypeOf(uint64(0)))
+	}
+	n, err := strconv.ParseUint(v.str, 10, 64)
+	if err != nil {
+		return 0, v.errType(reflect.TypeOf(uint64(0)))
+	}
+	return n, nil
+}
+
+// Float returns the number as float64.
+func (v *Value) Float() (float64, error) {
+	if v.Kind() != NumberValue {
+		return 0, v.errType(reflect.TypeOf(float64(0)))
+	}
+	f, err := strconv.ParseFloat(v.str, 64)
+	if err != nil {
+		return 0, v.errType(reflect.TypeOf(float64(0)))
+	}
+	return f, nil
+}
+
+// Number returns the literal of the number.
+func (v *Value) Number() (json.Number, error) {
+	if v.Kind() != NumberValue {
+		return "", v.errType(reflect.TypeOf(json.Number("")))
+	}
+	return json.Number(v.str), nil
+}
+
+// Str returns the string value.
+// It's not named String because String returns the JSON encoding of the value.
+func (v *Value) Str() (string, error) {
+	if v.Kind() != StringValue {
+		return "", v.errType(reflect.TypeOf(""))
+	}
+	return v.str, nil
+}
+
+func (v *Value) errType(typ reflect.Type) *errors.UnmarshalTypeError {
+	kind := v.Kind()
+	value := kind.String()
+	switch kind {
+	case InvalidValue:
+		value = "missing value"
+	case NumberValue:
+		value = "number " + v.str
+	}
+	return &errors.UnmarshalTypeError{Value: value, Type: typ}
+}
+
+// Len returns the number of the elements of the array or the members of the object, and 0 for the other kinds.
+func (v *Value) Len() int {
+	switch v.Kind() {
+	case ArrayValue:
+		return len(v.elems)
+	case ObjectValue:
+		return len(v.members)
+	}
+	return 0
+}
+
+// Keys returns the keys of the object in order.
+func (v *Value) Keys() []string {
+	if v.Kind() != ObjectValue {
+		return nil
+	}
+	keys := make([]string, 0, len(v.members))
+	for _, m := range v.members {
+		keys = append(keys, m.key)
+	}
+	return keys
+}
+
+// Each calls fn for each member of the object in order until fn returns false.
+func (v *Value) Each(fn func(key string, value *Value) bool) {
+	if v.Kind() != ObjectValue {
+		return
+	}
+	for _, m := range v.members {
+		if !fn(m.key, m.value) {
+			return
+		}
+	}
+}
+
+// EachElem calls fn for each element of the array in order until fn returns false.
+func (v *Value) EachElem(fn func(i int, value *Value) bool) {
+	if v.Kind() != ArrayValue {
+		return
+	}
+	for i, elem := range v.elems {
+		if !fn(i, elem) {
+			return
+		}
+	}
+}
+
+// Set sets the member of the object with key to value converted by ValueOf.
+// The existing member keeps its position, and the new member is appended to the end.
+func (v *Value) Set(key string, value interface{}) error {
+	if v.Kind() != ObjectValue {
+		return fmt.Errorf("json: cannot set a member of %s", v.Kind())
+	}
+	elem, err := ValueOf(value)
+	if err != nil {
+		return err
+	}
+	v.setMember(key, elem)
+	return nil
+}
+
+func (v *Value) setMember(key string, elem *Value) {
+	if i := v.memberIndex(key); i >= 0 {
+		v.members[i].value = elem
+		return
+	}
+	v.members = append(v.members, valueMember{key: key, value: elem})
+}
+
+// dedupeMembers resolves the duplicated keys of the decoded object once all members are appended.
+// The last value wins and keeps the position of the first member in the same way as setMember.
+func (v *Value) dedupeMembers() {
+	if len(v.members) < 2 {
+		return
+	}
+	var index map[string]int
+	if len(v.members) > smallObjectSize {
+		index = make(map[string]int, len(v.members))
+	}
+	members := v.members[:0]
+	for _, m := range v.members {
+		i := -1
+		if index != nil {
+			if idx, exists := index[m.key]; exists {
+				i = idx
+			}
+		} else {
+			for j := range members {
+				if members[j].key == m.key {
+					i = j
+					break
+				}
+			}
+		}
+		if i >= 0 {
+			members[i].value = m.value
+			continue
+		}
+		if index != nil {
+			index[m.key] = len(members)
+		}
+		members = append(members, m)
+	}
+	v.members = members
+}
+
+// smallObjectSize is the number of members up to which the duplicated keys are searched linearly without making a map.
+const smallObjectSize = 8
+
+// SetIndex sets the i-th element of the array to value converted by ValueOf.
+func (v *Value) SetIndex(i int, value interface{}) error {
+	if v.Kind() != ArrayValue {
+		return fmt.Errorf("json: cannot set an element of %s", v.Kind())
+	}
+	if i < 0 || i >= len(v.elems) {
+		return fmt.Errorf("json: index %d out of range of array of length %d", i, len(v.elems))
+	}
+	elem, err := ValueOf(value)
+	if err != nil {
+		return err
+	}
+	v.elems[i] = elem
+	return nil
+}
+
+// Append appends values converted by ValueOf to the array.
+func (v *Value) Append(values ...interface{}) error {
+	if v.Kind() != ArrayValue {
+		return fmt.Errorf("json: cannot append to %s", v.Kind())
+	}
+	for _, value := range values {
+		elem, err := ValueOf(value)
+		if err != nil {
+			return err
+		}
+		v.elems = append(v.elems, elem)
+	}
+	return nil
+}
+
+// Delete removes the member of the object with key, and reports whether it existed.
+func (v *Value) Delete(key string) bool {
+	if v.Kind() != ObjectValue {
+		return false
+	}
+	i := v.memberIndex(key)
+	if i < 0 {
+		return false
+	}
+	v.members = append(v.members[:i], v.members[i+1:]...)
+	return true
+}
+
+// DeleteIndex removes the i-th element of the array, and reports whether it existed.
+func (v *Value) DeleteIndex(i int) bool {
+	if v.Kind() != ArrayValue || i < 0 || i >= len(v.elems) {
+		return false
+	}
+	v.elems = append(v.elems[:i], v.elems[i+1:]...)
+	return true
+}
+
+// Clone returns the deep copy of the value.
+func (v *Value) Clone() *Value {
+	if v == nil {
+		return nil
+	}
+	cloned := &Value{kind: v.kind, boolean: v.boolean, str: v.str}
+	if v.elems != nil {
+		cloned.elems = make([]*Value, 0, len(v.elems))
+		for _, elem := range v.elems {
+			cloned.elems = append(cloned.elems, elem.Clone())
+		}
+	}
+	if v.members != nil {
+		cloned.members = make([]valueMember, 0, len(v.members))
+		for _, m := range v.members {
+			cloned.members = append(cloned.members, valueMember{key: m.key, value: m.value.Clone()})
+		}
+	}
+	return cloned
+}
+
+// Interface converts the value into the Go value that Unmarshal makes for interface{}.
+func (v *Value) Interface() interface{} {
+	switch v.Kind() {
+	case BoolValue:
+		return v.boolean
+	case NumberValue:
+		f, _ := strconv.ParseFloat(v.str, 64)
+		return f
+	case StringValue:
+		return v.str
+	case ArrayValue:
+		elems := make([]interface{}, 0, len(v.elems))
+		for _, elem := range v.elems {
+			elems = append(elems, elem.Interface())
+		}
+		return elems
+	case ObjectValue:
+		members := make(map[string]interface{}, len(v.members))
+		for _, m := range v.members {
+			members[m.key] = m.value.Interface()
+		}
+		return members
+	}
+	return nil
+}
+
+// String returns the JSON encoding of the value, and the empty string if the value doesn't exist.
+func (v *Value) String() string {
+	if v == nil {
+		return ""
+	}
+	b, _ := v.MarshalJSON()
+	return string(b)
+}
+
+// MarshalJSON encodes the value directly without reflection.
+// HTML characters are left unescaped here and are escaped by the encoder calling it as configured.
+// It has the value receiver, so that Value is encoded even if it isn't addressable.
+func (v Value) MarshalJSON() ([]byte, error) {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = encoder.NormalizeUTF8Option
+	b := v.appendJSON(ctx, nil)
+	encoder.ReleaseRuntimeContext(ctx)
+	return b, nil
+}
+
+func (v *Value) appendJSON(ctx *encoder.RuntimeContext, b []byte) []byte {
+	switch v.Kind() {
+	case BoolValue:
+		return strconv.AppendBool(b, v.boolean)
+	case NumberValue:
+		return append(b, v.str...)
+	case StringValue:
+		return encoder.AppendString(ctx, b, v.str)
+	case ArrayValue:
+		b = append(b, '[')
+		for i, elem := range v.elems {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = elem.appendJSON(ctx, b)
+		}
+		return append(b, ']')
+	case ObjectValue:
+		b = append(b, '{')
+		for i, m := range v.members {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = encoder.AppendString(ctx, b, m.key)
+			b = append(b, ':')
+			b = m.value.appendJSON(ctx, b)
+		}
+		return append(b, '}')
+	}
+	return append(b, "null"...)
+}
+
+// UnmarshalJSON decodes data into the value.
+// It's used when the value is decoded by the other packages, and the decoder of this package decodes it directly.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseValue(data)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// lookup returns the value at location, which is reported by the RFC 9535 query.
+func (v *Value) lookup(location []pathLocation) *Value {
+	for _, loc := range location {
+		if loc.isIndex {
+			v = v.elem(loc.index)
+		} else {
+			v = v.member(loc.field)
+		}
+	}
+	return v
+}
+
+var (
+	valueType           = runtime.Type2RType(reflect.TypeOf(Value{}))
+	valueReflectType    = reflect.TypeOf(Value{})
+	valuePtrReflectType = reflect.TypeOf(&Value{})
+	valueDec            = newValueDecoder("", "")
+)
+
+// valueDecoder decodes JSON into Value directly.
+type valueDecoder struct {
+	stringDecoder *stringDecoder
+	structName    string
+	fieldName     string
+}
+
+func newValueDecoder(structName, fieldName string) *valueDecoder {
+	return &valueDecoder{
+		stringDecoder: newStringDecoder(structName, fieldName),
+		structName:    structName,
+		fieldName:     fieldName,
+	}
+}
+
+func (d *valueDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	return d.decodeStream(s, depth, (*Value)(p))
+}
+
+func (d *valueDecoder) decodeStream(s *Stream, depth int64, v *Value) error {
+	c, err := s.skipWhiteSpaceWithOption()
+	if err != nil {
+		return err
+	}
+	relaxed := isRelaxed(s.Option)
+	for {
+		switch c {
+		case '{':
+			depth++
+			if depth > maxDecodeNestingDepth {
+				return errors.ErrExceededMaxDepth(c, s.cursor)
+			}
+			s.cursor++
+			*v = Value{kind: ObjectValue, members: []valueMember{}}
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == '}' {
+				s.cursor++
+				return nil
+			}
+			for {
+				if err := s.checkContext(); err != nil {
+					return err
+				}
+				var key []byte
+				switch c := s.skipWhiteSpace(); {
+				case relaxed && isRelaxedKeyStart(c):
+					key, err = s.decodeRelaxedKey()
+				case c == '"':
+					key, err = stringBytes(s)
+				default:
+					return errors.ErrExpected("object key", s.totalOffset())
+				}
+				if err != nil {
+					return err
+				}
+				k := string(key)
+				if _, err := s.skipWhiteSpaceWithOption(); err != nil {
+					return err
+				}
+				if !s.equalChar(':') {
+					return errors.ErrExpected("colon after object key", s.totalOffset())
+				}
+				s.cursor++
+				elem := &Value{}
+				if err := d.decodeStream(s, depth, elem); err != nil {
+					return err
+				}
+				v.members = append(v.members, valueMember{key: k, value: elem})
+				c, err := s.skipWhiteSpaceWithOption()
+				if err != nil {
+					return err
+				}
+				switch c {
+				case '}':
+					s.cursor++
+					v.dedupeMembers()
+					return nil
+				case ',':
+					s.cursor++
+				default:
+					return errors.ErrExpected("comma after object value", s.totalOffset())
+				}
+				if relaxed {
+					// the trailing comma is allowed.
+					c, err := s.skipWhiteSpaceWithOption()
+					if err != nil {
+						return err
+					}
+					if c == '}' {
+						s.cursor++
+						v.dedupeMembers()
+						return nil
+					}
+				}
+			}
+		case '[':
+			depth++
+			if depth > maxDecodeNestingDepth {
+				return errors.ErrExceededMaxDepth(c, s.cursor)
+			}
+			s.cursor++
+			*v = Value{kind: ArrayValue, elems: []*Value{}}
+			c, err := s.skipWhiteSpaceWithOption()
+			if err != nil {
+				return err
+			}
+			if c == ']' {
+				s.cursor++
+				return nil
+			}
+			for {
+				if err := s.checkContext(); err != nil {
+					return err
+				}
+				elem := &Value{}
+				if err := d.decodeStream(s, depth, elem); err != nil {
+					return err
+				}
+				v.elems = append(v.elems, elem)
+				c, err := s.skipWhiteSpaceWithOption()
+				if err != nil {
+					return err
+				}
+				switch c {
+				case ']':
+					s.cursor++
+					return nil
+				case ',':
+					s.cursor++
+				default:
+					return errors.ErrExpected("comma after array element", s.totalOffset())
+				}
+				if relaxed {
+					// the trailing comma is allowed.
+					c, err := s.skipWhiteSpaceWithOption()
+					if err != nil {
+						return err
+					}
+					if c == ']' {
+						s.cursor++
+						return nil
+					}
+				}
+			}
+		case '"':
+			str, err := stringBytes(s)
+			if err != nil {
+				return err
+			}
+			*v = Value{kind: StringValue, str: string(str)}
+			return nil
+		case '\'':
+			if !relaxed {
+				break
+			}
+			str, err := s.decodeSingleQuotedString()
+			if err != nil {
+				return err
+			}
+			*v = Value{kind: StringValue, str: string(str)}
+			return nil
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			num := floatBytes(s)
+			if _, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&num)), 64); err != nil {
+				return errors.ErrSyntax(err.Error(), s.totalOffset())
+			}
+			*v = Value{kind: NumberValue, str: string(num)}
+			return nil
+		case 't':
+			if err := trueBytes(s); err != nil {
+				return err
+			}
+			*v = Value{kind: BoolValue, boolean: true}
+			return nil
+		case 'f':
+			if err := falseBytes(s); err != nil {
+				return err
+			}
+			*v = Value{kind: BoolValue}
+			return nil
+		case 'n':
+			if err := nullBytes(s); err != nil {
+				return err
+			}
+			*v = Value{kind: NullValue}
+			return nil
+		case nul:
+			if s.read() {
+				c = s.char()
+				continue
+			}
+		}
+		break
+	}
+	return errors.ErrInvalidBeginningOfValue(c, s.totalOffset())
+}
+
+func (d *valueDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	return d.decode(ctx, cursor, depth, (*Value)(p))
+}
+
+func (d *valueDecoder) decode(ctx *RuntimeContext, cursor, depth int64, v *Value) (int64, error) {
+	buf := ctx.Buf
+	relaxed := isRelaxed(ctx.Option)
+	cursor, err := skipWhiteSpaceWithOption(ctx, cursor)
+	if err != nil {
+		return 0, err
+	}
+	switch buf[cursor] {
+	case '{':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		}
+		*v = Value{kind: ObjectValue, members: []valueMember{}}
+		cursor, err = skipWhiteSpaceWithOption(ctx, cursor+1)
+		if err != nil {
+			return 0, err
+		}
+		if buf[cursor] == '}' {
+			return cursor + 1, nil
+		}
+		for {
+			var (
+				key []byte
+				c   int64
+			)
+			switch {
+			case relaxed && isRelaxedKeyStart(buf[cursor]):
+				key, c, err = decodeRelaxedKey(buf, cursor)
+			case buf[cursor] == '"':
+				key, c, err = d.stringDecoder.decodeByte(buf, cursor)
+			default:
+				return 0, errors.ErrExpected("object key", cursor)
+			}
+			if err != nil {
+				return 0, err
+			}
+			cursor, err = skipWhiteSpaceWithOption(ctx, c)
+			if err != nil {
+				return 0, err
+			}
+			if buf[cursor] != ':' {
+				return 0, errors.ErrExpected("colon after object key", cursor)
+			}
+			elem := &Value{}
+			c, err = d.decode(ctx, cursor+1, depth, elem)
+			if err != nil {
+				return 0, err
+			}
+			v.members = append(v.members, valueMember{key: string(key), value: elem})
+			cursor, err = skipWhiteSpaceWithOption(ctx, c)
+			if err != nil {
+				return 0, err
+			}
+			switch buf[cursor] {
+			case '}':
+				v.dedupeMembers()
+				return cursor + 1, nil
+			case ',':
+				cursor, err = skipWhiteSpaceWithOption(ctx, cursor+1)
+				if err != nil {
+					return 0, err
+				}
+				if relaxed && buf[cursor] == '}' {
+					// the trailing comma is allowed.
+					v.dedupeMembers()
+					return cursor + 1, nil
+				}
+			default:
+				return 0, errors.ErrExpected("comma after object value", cursor)
+			}
+		}
+	case '[':
+		depth++
+		if depth > maxDecodeNestingDepth {
+			return 0, errors.ErrExceededMaxDepth(buf[cursor], cursor)
+		}
+		*v = Value{kind: ArrayValue, elems: []*Value{}}
+		cursor, err = skipWhiteSpaceWithOption(ctx, cursor+1)
+		if err != nil {
+			return 0, err
+		}
+		if buf[cursor] == ']' {
+			return cursor + 1, nil
+		}
+		for {
+			elem := &Value{}
+			c, err := d.decode(ctx, cursor, depth, elem)
+			if err != nil {
+				return 0, err
+			}
+			v.elems = append(v.elems, elem)
+			cursor, err = skipWhiteSpaceWithOption(ctx, c)
+			if err != nil {
+				return 0, err
+			}
+			switch buf[cursor] {
+			case ']':
+				return cursor + 1, nil
+			case ',':
+				cursor++
+			default:
+				return 0, errors.ErrExpected("comma after array element", cursor)
+			}
+			if relaxed {
+				// the trailing comma is allowed.
+				if cursor, err = SkipRelaxedWhiteSpace(buf, cursor); err != nil {
+					return 0, err
+				}
+				if buf[cursor] == ']' {
+					return cursor + 1, nil
+				}
+			}
+		}
+	case '"':
+		str, c, err := d.stringDecoder.decodeByte(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		*v = Value{kind: StringValue, str: string(str)}
+		return c, nil
+	case '\'':
+		if !relaxed {
+			break
+		}
+		str, c, err := decodeSingleQuotedString(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		*v = Value{kind: StringValue, str: string(str)}
+		return c, nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		start := cursor
+		cursor++
+		for floatTable[buf[cursor]] {
+			cursor++
+		}
+		num := buf[start:cursor]
+		if _, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&num)), 64); err != nil {
+			return 0, errors.ErrSyntax(err.Error(), cursor)
+		}
+		*v = Value{kind: NumberValue, str: string(num)}
+		return cursor, nil
+	case 't':
+		if err := validateTrue(buf, cursor); err != nil {
+			return 0, err
+		}
+		*v = Value{kind: BoolValue, boolean: true}
+		return cursor + 4, nil
+	case 'f':
+		if err := validateFalse(buf, cursor); err != nil {
+			return 0, err
+		}
+		*v = Value{kind: BoolValue}
+		return cursor + 5, nil
+	case 'n':
+		if err := validateNull(buf, cursor); err != nil {
+			return 0, err
+		}
+		*v = Value{kind: NullValue}
+		return cursor + 4, nil
+	}
+	return 0, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
+}
+
+func (d *valueDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	return nil, 0, fmt.Errorf("json: value decoder does not support decode path")
+}
+
+// Values evaluates the path on v, and returns the matched values.
+// They are the references to the values in v, so they can be edited in place.
+// The values matched are the same as the ones of Matches for the JSON of v.
+func (p *Path) Values(v *Value) ([]*Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if p.query != nil {
+		ctx := &rfcEvalContext{root: &rfcPathNode{value: v.rfcValue()}}
+		nodes := p.query.eval(ctx, ctx.root)
+		values := make([]*Value, 0, len(nodes))
+		for _, node := range nodes {
+			values = append(values, v.lookup(node.location()))
+		}
+		return values, nil
+	}
+	if p.node == nil {
+		return []*Value{v}, nil
+	}
+	return v.pathValues(p.node, nil)
+}
+
+// pathValues appends the values matched by node to values, walking the value with the selector nodes in the same way as the path decoder.
+func (v *Value) pathValues(node PathNode, values []*Value) ([]*Value, error) {
+	switch v.Kind() {
+	case ObjectValue:
+		for _, m := range v.members {
+			child, found, err := node.Field(m.key)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			if child == nil {
+				values = append(values, m.value)
+				continue
+			}
+			if values, err = m.value.pathValues(child, values); err != nil {
+				return nil, err
+			}
+		}
+	case ArrayValue:
+		for i, elem := range v.elems {
+			child, found, err := node.Index(i)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			if child == nil {
+				values = append(values, elem)
+				continue
+			}
+			if values, err = elem.pathValues(child, values); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if isRecursiveTarget(node) {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// rfcValue converts v into the value the RFC 9535 query is evaluated on.
+func (v *Value) rfcValue() *rfcValue {
+	switch v.Kind() {
+	case BoolValue:
+		if v.boolean {
+			return &rfcValue{kind: rfcTrue}
+		}
+		return &rfcValue{kind: rfcFalse}
+	case NumberValue:
+		num, _ := strconv.ParseFloat(v.str, 64)
+		return &rfcValue{kind: rfcNumber, num: num}
+	case StringValue:
+		return &rfcValue{kind: rfcString, str: v.str}
+	case ArrayValue:
+		elems := make([]*rfcValue, 0, len(v.elems))
+		for _, elem := range v.elems {
+			elems = append(elems, elem.rfcValue())
+		}
+		return &rfcValue{kind: rfcArray, elems: elems}
+	case ObjectValue:
+		keys := make([]string, 0, len(v.members))
+		elems := make([]*rfcValue, 0, len(v.members))
+		for _, m := range v.members {
+			keys = append(keys, m.key)
+			elems = append(elems, m.value.rfcValue())
+		}
+		return &rfcValue{kind: rfcObject, keys: keys, elems: elems}
+	}
+	return &rfcValue{kind: rfcNull}
+}
@@ -56,6 +56,8 @@ const (
 	CodeKindMarshalJSON
 	CodeKindMarshalText
 	CodeKindRecursive
+	CodeKindRedact
+	CodeKindStream
 )
 
 type IntCode struct {
@@ -558,13 +560,9 @@ func (c *StructCode) enableIndirect() {
 }
 
 func (c *StructCode) Filter(query *FieldQuery) Code {
-	fieldMap := map[string]*FieldQuery{}
-	for _, field := range query.Fields {
-		fieldMap[field.Name] = field
-	}
 	fields := make([]*StructFieldCode, 0, len(c.fields))
 	for _, field := range c.fields {
-		query, exists := fieldMap[field.key]
+		fieldQuery, exists := query.SubQuery(field.key)
 		if !exists {
 			continue
 		}
@@ -580,9 +578,10 @@ func (c *StructCode) Filter(query *FieldQuery) Code {
 			isNilCheck:         field.isNilCheck,
 			isAddrForMarshaler: field.isAddrForMarshaler,
 			isNextOpPtrType:    field.isNextOpPtrType,
+			isRedact:           field.isRedact,
 		}
-		if len(query.Fields) > 0 {
-			fieldCode.value = fieldCode.value.Filter(query)
+		if fieldQuery != nil {
+			fieldCode.value = fieldCode.value.Filter(fieldQuery)
 		}
 		fields = append(fields, fieldCode)
 	}
@@ -609,6 +608,7 @@ type StructFieldCode struct {
 	isAddrForMarshaler bool
 	isNextOpPtrType    bool
 	isMarshalerContext bool
+	isRedact           bool
 }
 
 func (c *StructFieldCode) getStruct() *StructCode {
@@ -1005,6 +1005,8 @@ func convertPtrOp(code *Opcode) OpType {
 		return OpMarshalTextPtr
 	case OpInterface:
 		return OpInterfacePtr
+	case OpStream:
+		return OpStreamPtr
 	case OpRecursive:
 		return OpRecursivePtr
 	}
@@ -1021,3 +1023,87 @@ func isEmbeddedStruct(field *StructFieldCode) bool {
 	}
 	return t.Kind() == reflect.Struct
 }
+
+// RedactCode writes the redact mask instead of the value of the field tagged with redact.
+type RedactCode struct {
+	typ *runtime.Type
+}
+
+func (c *RedactCode) Kind() CodeKind {
+	return CodeKindRedact
+}
+
+func (c *RedactCode) ToOpcode(ctx *compileContext) Opcodes {
+	code := newOpCode(ctx, c.typ, OpRedact)
+	ctx.incIndex()
+	return Opcodes{code}
+}
+
+func (c *RedactCode) Filter(_ *FieldQuery) Code {
+	return c
+}
+
+// StreamCode encodes the elements produced by the channel or the iterator as array.
+type StreamCode struct {
+	typ *runtime.Type
+}
+
+func (c *StreamCode) Kind() CodeKind {
+	return CodeKindStream
+}
+
+func (c *StreamCode) ToOpcode(ctx *compileContext) Opcodes {
+	code := newOpCode(ctx, c.typ, OpStream)
+	ctx.incIndex()
+	return Opcodes{code}
+}
+
+func (c *StreamCode) Filter(_ *FieldQuery) Code {
+	return c
+}
+
+// redactCode replaces the values of the fields tagged with redact by RedactCode.
+// Pointers, slices, arrays and map values are traversed, so that nested structs are redacted too.
+func redactCode(code Code) Code {
+	return mapStructFields(code, func(field *StructFieldCode) *StructFieldCode {
+		if field.isRedact && !field.isAnonymous {
+			field.value = &RedactCode{typ: field.typ}
+		}
+		return field
+	})
+}
+
+// mapStructFields rebuilds code by applying fn to the copy of each struct field reached from code.
+// The field is removed if fn returns nil, otherwise its value is traversed in the same way.
+func mapStructFields(code Code, fn func(*StructFieldCode) *StructFieldCode) Code {
+	switch c := code.(type) {
+	case *StructCode:
+		fields := make([]*StructFieldCode, 0, len(c.fields))
+		for _, field := range c.fields {
+			fieldCode := *field
+			mapped := fn(&fieldCode)
+			if mapped == nil {
+				continue
+			}
+			mapped.value = mapStructFields(mapped.value, fn)
+			fields = append(fields, mapped)
+		}
+		return &StructCode{
+			typ:                       c.typ,
+			fields:                    fields,
+			isPtr:                     c.isPtr,
+			disableIndirectConversion: c.disableIndirectConversion,
+			isIndirect:                c.isIndirect,
+			isRecursive:               c.isRecursive,
+		}
+	case *PtrCode:
+		return &PtrCode{typ: c.typ, value: mapStructFields(c.value, fn), ptrNum: c.ptrNum}
+	case *SliceCode:
+		return &SliceCode{typ: c.typ, value: mapStructFields(c.value, fn)}
+	case *ArrayCode:
+		return &ArrayCode{typ: c.typ, value: mapStructFields(c.value, fn)}
+	case *MapCode:
+		return &MapCode{typ: c.typ, key: c.key, value: mapStructFields(c.value, fn)}
+	}
+	return code
+}
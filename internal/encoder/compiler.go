@@ -64,26 +64,54 @@ func compileToGetCodeSetSlowPath(typeptr uintptr) (*OpcodeSet, error) {
 }
 
 func getFilteredCodeSetIfNeeded(ctx *RuntimeContext, codeSet *OpcodeSet) (*OpcodeSet, error) {
-	if (ctx.Option.Flag & ContextOption) == 0 {
-		return codeSet, nil
+	var query *FieldQuery
+	if (ctx.Option.Flag & ContextOption) != 0 {
+		query = FieldQueryFromContext(ctx.Option.Context)
 	}
-	query := FieldQueryFromContext(ctx.Option.Context)
-	if query == nil {
+	redact := (ctx.Option.Flag & RedactOption) != 0
+	if query == nil && !redact && !codeSet.hasGroupsField {
 		return codeSet, nil
 	}
-	ctx.Option.Flag |= FieldQueryOption
-	cacheCodeSet := codeSet.getQueryCache(query.Hash())
+	var hash string
+	if query != nil {
+		ctx.Option.Flag |= FieldQueryOption
+		hash = query.Hash()
+	}
+	if redact {
+		// the redacted code set is cached separately from the one with the same query.
+		hash += redactCacheKeySuffix
+	}
+	var groups []string
+	if codeSet.hasGroupsField {
+		// the fields restricted to groups are omitted unless one of them is active,
+		// so one code set is cached for each combination of the active groups.
+		groups = activeGroups(ctx.Option)
+		hash += groupsCacheKey(groups)
+	}
+	cacheCodeSet := codeSet.getQueryCache(hash)
 	if cacheCodeSet != nil {
 		return cacheCodeSet, nil
 	}
-	queryCodeSet, err := newCompiler().codeToOpcodeSet(codeSet.Type, codeSet.Code.Filter(query))
+	code := codeSet.Code
+	if query != nil {
+		code = code.Filter(query)
+	}
+	if redact {
+		code = redactCode(code)
+	}
+	if codeSet.hasGroupsField {
+		code = filterGroupsCode(code, groups)
+	}
+	queryCodeSet, err := newCompiler().codeToOpcodeSet(codeSet.Type, code)
 	if err != nil {
 		return nil, err
 	}
-	codeSet.setQueryCache(query.Hash(), queryCodeSet)
+	codeSet.setQueryCache(hash, queryCodeSet)
 	return queryCodeSet, nil
 }
 
+const redactCacheKeySuffix = "#redact"
+
 type Compiler struct {
 	structTypeToCode map[uintptr]*StructCode
 }
@@ -134,6 +162,7 @@ func (c *Compiler) codeToOpcodeSet(typ *runtime.Type, code Code) (*OpcodeSet, er
 		EndCode:                  ToEndCode(interfaceNoescapeKeyCode),
 		Code:                     code,
 		QueryCache:               map[string]*OpcodeSet{},
+		hasGroupsField:           hasGroupsField(code),
 	}, nil
 }
 
@@ -204,6 +233,11 @@ func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
 		return c.boolCode(typ, isPtr)
 	case reflect.Interface:
 		return c.interfaceCode(typ, isPtr)
+	case reflect.Chan, reflect.Func:
+		if isPtr && isStreamType(typ) {
+			return c.ptrCode(orgType)
+		}
+		return c.typeToCodeWithPtr(typ, isPtr)
 	default:
 		if isPtr && typ.Implements(marshalTextType) {
 			typ = orgType
@@ -269,6 +303,10 @@ func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error
 		return c.stringCode(typ, false)
 	case reflect.Bool:
 		return c.boolCode(typ, false)
+	case reflect.Chan, reflect.Func:
+		if isStreamType(typ) {
+			return &StreamCode{typ: typ}, nil
+		}
 	}
 	return nil, &errors.UnsupportedTypeError{Type: runtime.RType2Type(typ)}
 }
@@ -637,6 +675,7 @@ func (c *Compiler) structFieldCode(structCode *StructCode, tag *runtime.StructTa
 		isTaggedKey:   tag.IsTaggedKey,
 		isNilableType: c.isNilableType(fieldType),
 		isNilCheck:    true,
+		isRedact:      tag.IsRedact,
 	}
 	switch {
 	case c.isMovePointerPositionFromHeadToFirstMarshalJSONFieldCase(fieldType, isIndirectSpecialCase):
@@ -685,7 +724,7 @@ func (c *Compiler) structFieldCode(structCode *StructCode, tag *runtime.StructTa
 			return nil, err
 		}
 		switch code.Kind() {
-		case CodeKindPtr, CodeKindInterface:
+		case CodeKindPtr, CodeKindInterface, CodeKindStream:
 			fieldCode.isNextOpPtrType = true
 		}
 		fieldCode.value = code
@@ -19,16 +19,12 @@ func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, erro
 	}
 	index := (typeptr - typeAddr.BaseTypeAddr) >> typeAddr.AddrShift
 	setsMu.RLock()
-	if codeSet := cachedOpcodeSets[index]; codeSet != nil {
-		filtered, err := getFilteredCodeSetIfNeeded(ctx, codeSet)
-		if err != nil {
-			setsMu.RUnlock()
-			return nil, err
-		}
-		setsMu.RUnlock()
-		return filtered, nil
-	}
+	codeSet := cachedOpcodeSets[index]
 	setsMu.RUnlock()
+	if codeSet != nil {
+		// filter without the lock, because FieldQuery.Hash may compile the other types.
+		return getFilteredCodeSetIfNeeded(ctx, codeSet)
+	}
 
 	codeSet, err := newCompiler().compile(typeptr)
 	if err != nil {
@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"context"
+	"io"
 	"sync"
 	"unsafe"
 
@@ -79,6 +80,10 @@ type RuntimeContext struct {
 	Prefix     []byte
 	IndentStr  []byte
 	Option     *Option
+
+	// Writer is the destination of Flush. The encoded bytes are only kept in the buffer if it's nil.
+	Writer         io.Writer
+	flushSuspended int
 }
 
 func (c *RuntimeContext) Init(p uintptr, codelen int) {
@@ -89,6 +94,41 @@ func (c *RuntimeContext) Init(p uintptr, codelen int) {
 	c.KeepRefs = c.KeepRefs[:0]
 	c.SeenPtr = c.SeenPtr[:0]
 	c.BaseIndent = 0
+	c.flushSuspended = 0
+}
+
+// flushKeepLen is the length of the tail kept by Flush,
+// because the following opcodes may rewrite it (e.g. the trailing comma replaced by the closing bracket).
+const flushKeepLen = 2
+
+// Flush writes the encoded bytes except the tail to Writer, and returns the buffer to continue encoding.
+func (c *RuntimeContext) Flush(b []byte) ([]byte, error) {
+	if c.Writer == nil || c.flushSuspended > 0 || len(b) <= flushKeepLen {
+		return b, nil
+	}
+	n := len(b) - flushKeepLen
+	if _, err := c.Writer.Write(b[:n]); err != nil {
+		return nil, err
+	}
+	return append(b[:0], b[n:]...), nil
+}
+
+// FlushIfExceeded flushes the buffer if it exceeds Option.FlushThreshold.
+func (c *RuntimeContext) FlushIfExceeded(b []byte) ([]byte, error) {
+	if c.Option.FlushThreshold <= 0 || len(b) < c.Option.FlushThreshold {
+		return b, nil
+	}
+	return c.Flush(b)
+}
+
+// SuspendFlush suspends Flush while the sorted map refers to the positions in the buffer.
+func (c *RuntimeContext) SuspendFlush() {
+	c.flushSuspended++
+}
+
+// ResumeFlush resumes Flush suspended by SuspendFlush.
+func (c *RuntimeContext) ResumeFlush() {
+	c.flushSuspended--
 }
 
 func (c *RuntimeContext) Ptr() uintptr {
@@ -101,5 +141,9 @@ func TakeRuntimeContext() *RuntimeContext {
 }
 
 func ReleaseRuntimeContext(ctx *RuntimeContext) {
+	// the groups, the flush threshold and the writer are only set for each call.
+	ctx.Option.Groups = nil
+	ctx.Option.FlushThreshold = 0
+	ctx.Writer = nil
 	runtimeContextPool.Put(ctx)
 }
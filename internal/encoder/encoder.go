@@ -103,6 +103,7 @@ type OpcodeSet struct {
 	EndCode                  *Opcode
 	Code                     Code
 	QueryCache               map[string]*OpcodeSet
+	hasGroupsField           bool
 	cacheMu                  sync.RWMutex
 }
 
@@ -187,6 +188,30 @@ func PtrToInterface(code *Opcode, p uintptr) interface{} {
 	}))
 }
 
+// IsEmptyRedactValue reports whether code masks the field value at p and the value is empty,
+// so that the field tagged with omitempty is omitted before it's masked.
+func IsEmptyRedactValue(code *Opcode, p uintptr) bool {
+	if code.Op != OpRedact {
+		return false
+	}
+	v := reflect.NewAt(runtime.RType2Type(code.Type), PtrToUnsafePtr(p)).Elem()
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
 func ErrUnsupportedValue(code *Opcode, ptr uintptr) *errors.UnsupportedValueError {
 	v := *(*interface{})(unsafe.Pointer(&emptyInterface{
 		typ: code.Type,
@@ -0,0 +1,202 @@
+package encoder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// ParseFieldSelector parses the field selector in the style of partial response (e.g. `id,name,friends(id,name)`).
+//
+//	selector = field *( "," field )
+//	field    = [ "-" ] name [ "/" field ] [ "(" selector ")" ]
+//
+// "a/b" is the same as "a(b)", and "-" excludes the field instead of selecting it.
+func ParseFieldSelector(s string) (*FieldQuery, error) {
+	p := &fieldSelectorParser{src: s}
+	fields, err := p.parseSelector()
+	if err != nil {
+		return nil, err
+	}
+	if p.cursor < len(p.src) {
+		return nil, p.error("unexpected %q", p.src[p.cursor])
+	}
+	return &FieldQuery{Fields: fields}, nil
+}
+
+type fieldSelectorParser struct {
+	src    string
+	cursor int
+}
+
+func (p *fieldSelectorParser) error(msg string, args ...interface{}) error {
+	return errors.ErrSyntax(
+		fmt.Sprintf("json: invalid field selector %q at %d: %s", p.src, p.cursor, fmt.Sprintf(msg, args...)),
+		int64(p.cursor),
+	)
+}
+
+func (p *fieldSelectorParser) skipWhiteSpace() {
+	for p.cursor < len(p.src) && (p.src[p.cursor] == ' ' || p.src[p.cursor] == '\t') {
+		p.cursor++
+	}
+}
+
+func (p *fieldSelectorParser) char() byte {
+	if p.cursor >= len(p.src) {
+		return 0
+	}
+	return p.src[p.cursor]
+}
+
+func (p *fieldSelectorParser) parseSelector() ([]*FieldQuery, error) {
+	var fields []*FieldQuery
+	for {
+		p.skipWhiteSpace()
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		p.skipWhiteSpace()
+		if p.char() != ',' {
+			return fields, nil
+		}
+		p.cursor++
+	}
+}
+
+func (p *fieldSelectorParser) parseField() (*FieldQuery, error) {
+	exclude := p.char() == '-'
+	if exclude {
+		p.cursor++
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if exclude {
+		// the excluded field is represented by the dot separated path.
+		for p.char() == '/' {
+			p.cursor++
+			sub, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			name += "." + sub
+		}
+		if p.char() == '(' {
+			return nil, p.error("excluded field %q cannot have sub fields", name)
+		}
+		return &FieldQuery{Name: name, Exclude: true}, nil
+	}
+	field := &FieldQuery{Name: name}
+	switch p.char() {
+	case '/':
+		p.cursor++
+		sub, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		field.Fields = []*FieldQuery{sub}
+	case '(':
+		p.cursor++
+		fields, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		if p.char() != ')' {
+			return nil, p.error("expected )")
+		}
+		p.cursor++
+		field.Fields = fields
+	}
+	return field, nil
+}
+
+func (p *fieldSelectorParser) parseName() (string, error) {
+	start := p.cursor
+	for p.cursor < len(p.src) {
+		switch p.src[p.cursor] {
+		case ',', '(', ')', '/', ' ', '\t':
+		default:
+			p.cursor++
+			continue
+		}
+		break
+	}
+	if p.cursor == start {
+		if p.cursor >= len(p.src) {
+			return "", p.error("unexpected end of selector")
+		}
+		return "", p.error("expected field name but got %q", p.src[p.cursor])
+	}
+	return p.src[start:p.cursor], nil
+}
+
+// Validate reports an error if the query refers to a field that doesn't exist in typ.
+// Pointers, slices, arrays and map values are followed to find the struct to validate sub fields against.
+func (q *FieldQuery) Validate(typ reflect.Type) error {
+	return q.validate(typ, q.Fields)
+}
+
+func (q *FieldQuery) validate(typ reflect.Type, fields []*FieldQuery) error {
+	for _, field := range fields {
+		path := []string{field.Name}
+		if field.Exclude {
+			path = strings.Split(field.Name, ".")
+		}
+		fieldType := typ
+		for _, name := range path {
+			t, err := fieldTypeByKey(fieldType, name)
+			if err != nil {
+				return err
+			}
+			fieldType = t
+		}
+		if len(field.Fields) > 0 {
+			if err := q.validate(fieldType, field.Fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldTypeByKey returns the type of the struct field encoded with the key.
+func fieldTypeByKey(typ reflect.Type, key string) (reflect.Type, error) {
+	structType := typ
+	for {
+		switch structType.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			structType = structType.Elem()
+			continue
+		}
+		break
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("json: cannot select field %q of non struct type %s", key, typ)
+	}
+	if t, found := structFieldTypeByKey(structType, key); found {
+		return t, nil
+	}
+	return nil, fmt.Errorf("json: unknown field %q in type %s", key, structType)
+}
+
+// structFieldTypeByKey looks up the field in the same way as the filter of the struct code,
+// so the embedded struct is selected by its type name and the fields promoted from it aren't.
+func structFieldTypeByKey(typ reflect.Type, key string) (reflect.Type, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if runtime.IsIgnoredStructField(field) {
+			continue
+		}
+		if runtime.StructTagFromField(field).Key == key {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
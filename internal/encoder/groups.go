@@ -0,0 +1,81 @@
+package encoder
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+type groupsKey struct{}
+
+// GroupsFromContext returns the active groups set to the context.
+func GroupsFromContext(ctx context.Context) []string {
+	groups, _ := ctx.Value(groupsKey{}).([]string)
+	return groups
+}
+
+// SetGroupsToContext sets the active groups to the context.
+func SetGroupsToContext(ctx context.Context, groups ...string) context.Context {
+	return context.WithValue(ctx, groupsKey{}, groups)
+}
+
+// activeGroups returns the groups enabled by the option and the context, sorted and without duplicates.
+func activeGroups(opt *Option) []string {
+	groups := append([]string{}, opt.Groups...)
+	if (opt.Flag & ContextOption) != 0 {
+		groups = append(groups, GroupsFromContext(opt.Context)...)
+	}
+	sort.Strings(groups)
+	uniq := groups[:0]
+	for i, group := range groups {
+		if i > 0 && groups[i-1] == group {
+			continue
+		}
+		uniq = append(uniq, group)
+	}
+	return uniq
+}
+
+func groupsCacheKey(groups []string) string {
+	return "#groups=" + strings.Join(groups, "|")
+}
+
+// filterGroupsCode removes the fields restricted to the groups none of which is active.
+func filterGroupsCode(code Code, groups []string) Code {
+	return mapStructFields(code, func(field *StructFieldCode) *StructFieldCode {
+		if field.tag == nil || len(field.tag.Groups) == 0 {
+			return field
+		}
+		for _, group := range field.tag.Groups {
+			idx := sort.SearchStrings(groups, group)
+			if idx < len(groups) && groups[idx] == group {
+				return field
+			}
+		}
+		return nil
+	})
+}
+
+// hasGroupsField reports whether code has the fields restricted to groups.
+func hasGroupsField(code Code) bool {
+	switch c := code.(type) {
+	case *StructCode:
+		for _, field := range c.fields {
+			if field.tag != nil && len(field.tag.Groups) > 0 {
+				return true
+			}
+			if hasGroupsField(field.value) {
+				return true
+			}
+		}
+	case *PtrCode:
+		return hasGroupsField(c.value)
+	case *SliceCode:
+		return hasGroupsField(c.value)
+	case *ArrayCode:
+		return hasGroupsField(c.value)
+	case *MapCode:
+		return hasGroupsField(c.value)
+	}
+	return false
+}
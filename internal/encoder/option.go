@@ -5,7 +5,7 @@ import (
 	"io"
 )
 
-type OptionFlag uint8
+type OptionFlag uint16
 
 const (
 	HTMLEscapeOption OptionFlag = 1 << iota
@@ -16,14 +16,27 @@ const (
 	ContextOption
 	NormalizeUTF8Option
 	FieldQueryOption
+	RedactOption
 )
 
+// DefaultRedactMask is written instead of the values of the redacted fields by default.
+const DefaultRedactMask = "***"
+
 type Option struct {
 	Flag        OptionFlag
 	ColorScheme *ColorScheme
 	Context     context.Context
 	DebugOut    io.Writer
 	DebugDOTOut io.WriteCloser
+	RedactMask  string
+	Groups      []string
+
+	// Prefix and Indent are used to indent the output when IndentOption is set by the option.
+	Prefix string
+	Indent string
+
+	// FlushThreshold is the size of the buffer to flush to RuntimeContext.Writer during encoding.
+	FlushThreshold int
 }
 
 type EncodeFormat struct {
@@ -22,7 +22,7 @@ const (
 	CodeStructEnd   CodeType = 11
 )
 
-var opTypeStrings = [400]string{
+var opTypeStrings = [403]string{
 	"End",
 	"Interface",
 	"Ptr",
@@ -37,6 +37,9 @@ var opTypeStrings = [400]string{
 	"RecursivePtr",
 	"RecursiveEnd",
 	"InterfaceEnd",
+	"Redact",
+	"Stream",
+	"StreamPtr",
 	"Int",
 	"Uint",
 	"Float32",
@@ -442,396 +445,399 @@ const (
 	OpRecursivePtr                           OpType = 11
 	OpRecursiveEnd                           OpType = 12
 	OpInterfaceEnd                           OpType = 13
-	OpInt                                    OpType = 14
-	OpUint                                   OpType = 15
-	OpFloat32                                OpType = 16
-	OpFloat64                                OpType = 17
-	OpBool                                   OpType = 18
-	OpString                                 OpType = 19
-	OpBytes                                  OpType = 20
-	OpNumber                                 OpType = 21
-	OpArray                                  OpType = 22
-	OpMap                                    OpType = 23
-	OpSlice                                  OpType = 24
-	OpStruct                                 OpType = 25
-	OpMarshalJSON                            OpType = 26
-	OpMarshalText                            OpType = 27
-	OpIntString                              OpType = 28
-	OpUintString                             OpType = 29
-	OpFloat32String                          OpType = 30
-	OpFloat64String                          OpType = 31
-	OpBoolString                             OpType = 32
-	OpStringString                           OpType = 33
-	OpNumberString                           OpType = 34
-	OpIntPtr                                 OpType = 35
-	OpUintPtr                                OpType = 36
-	OpFloat32Ptr                             OpType = 37
-	OpFloat64Ptr                             OpType = 38
-	OpBoolPtr                                OpType = 39
-	OpStringPtr                              OpType = 40
-	OpBytesPtr                               OpType = 41
-	OpNumberPtr                              OpType = 42
-	OpArrayPtr                               OpType = 43
-	OpMapPtr                                 OpType = 44
-	OpSlicePtr                               OpType = 45
-	OpMarshalJSONPtr                         OpType = 46
-	OpMarshalTextPtr                         OpType = 47
-	OpInterfacePtr                           OpType = 48
-	OpIntPtrString                           OpType = 49
-	OpUintPtrString                          OpType = 50
-	OpFloat32PtrString                       OpType = 51
-	OpFloat64PtrString                       OpType = 52
-	OpBoolPtrString                          OpType = 53
-	OpStringPtrString                        OpType = 54
-	OpNumberPtrString                        OpType = 55
-	OpStructHeadInt                          OpType = 56
-	OpStructHeadOmitEmptyInt                 OpType = 57
-	OpStructPtrHeadInt                       OpType = 58
-	OpStructPtrHeadOmitEmptyInt              OpType = 59
-	OpStructHeadUint                         OpType = 60
-	OpStructHeadOmitEmptyUint                OpType = 61
-	OpStructPtrHeadUint                      OpType = 62
-	OpStructPtrHeadOmitEmptyUint             OpType = 63
-	OpStructHeadFloat32                      OpType = 64
-	OpStructHeadOmitEmptyFloat32             OpType = 65
-	OpStructPtrHeadFloat32                   OpType = 66
-	OpStructPtrHeadOmitEmptyFloat32          OpType = 67
-	OpStructHeadFloat64                      OpType = 68
-	OpStructHeadOmitEmptyFloat64             OpType = 69
-	OpStructPtrHeadFloat64                   OpType = 70
-	OpStructPtrHeadOmitEmptyFloat64          OpType = 71
-	OpStructHeadBool                         OpType = 72
-	OpStructHeadOmitEmptyBool                OpType = 73
-	OpStructPtrHeadBool                      OpType = 74
-	OpStructPtrHeadOmitEmptyBool             OpType = 75
-	OpStructHeadString                       OpType = 76
-	OpStructHeadOmitEmptyString              OpType = 77
-	OpStructPtrHeadString                    OpType = 78
-	OpStructPtrHeadOmitEmptyString           OpType = 79
-	OpStructHeadBytes                        OpType = 80
-	OpStructHeadOmitEmptyBytes               OpType = 81
-	OpStructPtrHeadBytes                     OpType = 82
-	OpStructPtrHeadOmitEmptyBytes            OpType = 83
-	OpStructHeadNumber                       OpType = 84
-	OpStructHeadOmitEmptyNumber              OpType = 85
-	OpStructPtrHeadNumber                    OpType = 86
-	OpStructPtrHeadOmitEmptyNumber           OpType = 87
-	OpStructHeadArray                        OpType = 88
-	OpStructHeadOmitEmptyArray               OpType = 89
-	OpStructPtrHeadArray                     OpType = 90
-	OpStructPtrHeadOmitEmptyArray            OpType = 91
-	OpStructHeadMap                          OpType = 92
-	OpStructHeadOmitEmptyMap                 OpType = 93
-	OpStructPtrHeadMap                       OpType = 94
-	OpStructPtrHeadOmitEmptyMap              OpType = 95
-	OpStructHeadSlice                        OpType = 96
-	OpStructHeadOmitEmptySlice               OpType = 97
-	OpStructPtrHeadSlice                     OpType = 98
-	OpStructPtrHeadOmitEmptySlice            OpType = 99
-	OpStructHeadStruct                       OpType = 100
-	OpStructHeadOmitEmptyStruct              OpType = 101
-	OpStructPtrHeadStruct                    OpType = 102
-	OpStructPtrHeadOmitEmptyStruct           OpType = 103
-	OpStructHeadMarshalJSON                  OpType = 104
-	OpStructHeadOmitEmptyMarshalJSON         OpType = 105
-	OpStructPtrHeadMarshalJSON               OpType = 106
-	OpStructPtrHeadOmitEmptyMarshalJSON      OpType = 107
-	OpStructHeadMarshalText                  OpType = 108
-	OpStructHeadOmitEmptyMarshalText         OpType = 109
-	OpStructPtrHeadMarshalText               OpType = 110
-	OpStructPtrHeadOmitEmptyMarshalText      OpType = 111
-	OpStructHeadIntString                    OpType = 112
-	OpStructHeadOmitEmptyIntString           OpType = 113
-	OpStructPtrHeadIntString                 OpType = 114
-	OpStructPtrHeadOmitEmptyIntString        OpType = 115
-	OpStructHeadUintString                   OpType = 116
-	OpStructHeadOmitEmptyUintString          OpType = 117
-	OpStructPtrHeadUintString                OpType = 118
-	OpStructPtrHeadOmitEmptyUintString       OpType = 119
-	OpStructHeadFloat32String                OpType = 120
-	OpStructHeadOmitEmptyFloat32String       OpType = 121
-	OpStructPtrHeadFloat32String             OpType = 122
-	OpStructPtrHeadOmitEmptyFloat32String    OpType = 123
-	OpStructHeadFloat64String                OpType = 124
-	OpStructHeadOmitEmptyFloat64String       OpType = 125
-	OpStructPtrHeadFloat64String             OpType = 126
-	OpStructPtrHeadOmitEmptyFloat64String    OpType = 127
-	OpStructHeadBoolString                   OpType = 128
-	OpStructHeadOmitEmptyBoolString          OpType = 129
-	OpStructPtrHeadBoolString                OpType = 130
-	OpStructPtrHeadOmitEmptyBoolString       OpType = 131
-	OpStructHeadStringString                 OpType = 132
-	OpStructHeadOmitEmptyStringString        OpType = 133
-	OpStructPtrHeadStringString              OpType = 134
-	OpStructPtrHeadOmitEmptyStringString     OpType = 135
-	OpStructHeadNumberString                 OpType = 136
-	OpStructHeadOmitEmptyNumberString        OpType = 137
-	OpStructPtrHeadNumberString              OpType = 138
-	OpStructPtrHeadOmitEmptyNumberString     OpType = 139
-	OpStructHeadIntPtr                       OpType = 140
-	OpStructHeadOmitEmptyIntPtr              OpType = 141
-	OpStructPtrHeadIntPtr                    OpType = 142
-	OpStructPtrHeadOmitEmptyIntPtr           OpType = 143
-	OpStructHeadUintPtr                      OpType = 144
-	OpStructHeadOmitEmptyUintPtr             OpType = 145
-	OpStructPtrHeadUintPtr                   OpType = 146
-	OpStructPtrHeadOmitEmptyUintPtr          OpType = 147
-	OpStructHeadFloat32Ptr                   OpType = 148
-	OpStructHeadOmitEmptyFloat32Ptr          OpType = 149
-	OpStructPtrHeadFloat32Ptr                OpType = 150
-	OpStructPtrHeadOmitEmptyFloat32Ptr       OpType = 151
-	OpStructHeadFloat64Ptr                   OpType = 152
-	OpStructHeadOmitEmptyFloat64Ptr          OpType = 153
-	OpStructPtrHeadFloat64Ptr                OpType = 154
-	OpStructPtrHeadOmitEmptyFloat64Ptr       OpType = 155
-	OpStructHeadBoolPtr                      OpType = 156
-	OpStructHeadOmitEmptyBoolPtr             OpType = 157
-	OpStructPtrHeadBoolPtr                   OpType = 158
-	OpStructPtrHeadOmitEmptyBoolPtr          OpType = 159
-	OpStructHeadStringPtr                    OpType = 160
-	OpStructHeadOmitEmptyStringPtr           OpType = 161
-	OpStructPtrHeadStringPtr                 OpType = 162
-	OpStructPtrHeadOmitEmptyStringPtr        OpType = 163
-	OpStructHeadBytesPtr                     OpType = 164
-	OpStructHeadOmitEmptyBytesPtr            OpType = 165
-	OpStructPtrHeadBytesPtr                  OpType = 166
-	OpStructPtrHeadOmitEmptyBytesPtr         OpType = 167
-	OpStructHeadNumberPtr                    OpType = 168
-	OpStructHeadOmitEmptyNumberPtr           OpType = 169
-	OpStructPtrHeadNumberPtr                 OpType = 170
-	OpStructPtrHeadOmitEmptyNumberPtr        OpType = 171
-	OpStructHeadArrayPtr                     OpType = 172
-	OpStructHeadOmitEmptyArrayPtr            OpType = 173
-	OpStructPtrHeadArrayPtr                  OpType = 174
-	OpStructPtrHeadOmitEmptyArrayPtr         OpType = 175
-	OpStructHeadMapPtr                       OpType = 176
-	OpStructHeadOmitEmptyMapPtr              OpType = 177
-	OpStructPtrHeadMapPtr                    OpType = 178
-	OpStructPtrHeadOmitEmptyMapPtr           OpType = 179
-	OpStructHeadSlicePtr                     OpType = 180
-	OpStructHeadOmitEmptySlicePtr            OpType = 181
-	OpStructPtrHeadSlicePtr                  OpType = 182
-	OpStructPtrHeadOmitEmptySlicePtr         OpType = 183
-	OpStructHeadMarshalJSONPtr               OpType = 184
-	OpStructHeadOmitEmptyMarshalJSONPtr      OpType = 185
-	OpStructPtrHeadMarshalJSONPtr            OpType = 186
-	OpStructPtrHeadOmitEmptyMarshalJSONPtr   OpType = 187
-	OpStructHeadMarshalTextPtr               OpType = 188
-	OpStructHeadOmitEmptyMarshalTextPtr      OpType = 189
-	OpStructPtrHeadMarshalTextPtr            OpType = 190
-	OpStructPtrHeadOmitEmptyMarshalTextPtr   OpType = 191
-	OpStructHeadInterfacePtr                 OpType = 192
-	OpStructHeadOmitEmptyInterfacePtr        OpType = 193
-	OpStructPtrHeadInterfacePtr              OpType = 194
-	OpStructPtrHeadOmitEmptyInterfacePtr     OpType = 195
-	OpStructHeadIntPtrString                 OpType = 196
-	OpStructHeadOmitEmptyIntPtrString        OpType = 197
-	OpStructPtrHeadIntPtrString              OpType = 198
-	OpStructPtrHeadOmitEmptyIntPtrString     OpType = 199
-	OpStructHeadUintPtrString                OpType = 200
-	OpStructHeadOmitEmptyUintPtrString       OpType = 201
-	OpStructPtrHeadUintPtrString             OpType = 202
-	OpStructPtrHeadOmitEmptyUintPtrString    OpType = 203
-	OpStructHeadFloat32PtrString             OpType = 204
-	OpStructHeadOmitEmptyFloat32PtrString    OpType = 205
-	OpStructPtrHeadFloat32PtrString          OpType = 206
-	OpStructPtrHeadOmitEmptyFloat32PtrString OpType = 207
-	OpStructHeadFloat64PtrString             OpType = 208
-	OpStructHeadOmitEmptyFloat64PtrString    OpType = 209
-	OpStructPtrHeadFloat64PtrString          OpType = 210
-	OpStructPtrHeadOmitEmptyFloat64PtrString OpType = 211
-	OpStructHeadBoolPtrString                OpType = 212
-	OpStructHeadOmitEmptyBoolPtrString       OpType = 213
-	OpStructPtrHeadBoolPtrString             OpType = 214
-	OpStructPtrHeadOmitEmptyBoolPtrString    OpType = 215
-	OpStructHeadStringPtrString              OpType = 216
-	OpStructHeadOmitEmptyStringPtrString     OpType = 217
-	OpStructPtrHeadStringPtrString           OpType = 218
-	OpStructPtrHeadOmitEmptyStringPtrString  OpType = 219
-	OpStructHeadNumberPtrString              OpType = 220
-	OpStructHeadOmitEmptyNumberPtrString     OpType = 221
-	OpStructPtrHeadNumberPtrString           OpType = 222
-	OpStructPtrHeadOmitEmptyNumberPtrString  OpType = 223
-	OpStructHead                             OpType = 224
-	OpStructHeadOmitEmpty                    OpType = 225
-	OpStructPtrHead                          OpType = 226
-	OpStructPtrHeadOmitEmpty                 OpType = 227
-	OpStructFieldInt                         OpType = 228
-	OpStructFieldOmitEmptyInt                OpType = 229
-	OpStructEndInt                           OpType = 230
-	OpStructEndOmitEmptyInt                  OpType = 231
-	OpStructFieldUint                        OpType = 232
-	OpStructFieldOmitEmptyUint               OpType = 233
-	OpStructEndUint                          OpType = 234
-	OpStructEndOmitEmptyUint                 OpType = 235
-	OpStructFieldFloat32                     OpType = 236
-	OpStructFieldOmitEmptyFloat32            OpType = 237
-	OpStructEndFloat32                       OpType = 238
-	OpStructEndOmitEmptyFloat32              OpType = 239
-	OpStructFieldFloat64                     OpType = 240
-	OpStructFieldOmitEmptyFloat64            OpType = 241
-	OpStructEndFloat64                       OpType = 242
-	OpStructEndOmitEmptyFloat64              OpType = 243
-	OpStructFieldBool                        OpType = 244
-	OpStructFieldOmitEmptyBool               OpType = 245
-	OpStructEndBool                          OpType = 246
-	OpStructEndOmitEmptyBool                 OpType = 247
-	OpStructFieldString                      OpType = 248
-	OpStructFieldOmitEmptyString             OpType = 249
-	OpStructEndString                        OpType = 250
-	OpStructEndOmitEmptyString               OpType = 251
-	OpStructFieldBytes                       OpType = 252
-	OpStructFieldOmitEmptyBytes              OpType = 253
-	OpStructEndBytes                         OpType = 254
-	OpStructEndOmitEmptyBytes                OpType = 255
-	OpStructFieldNumber                      OpType = 256
-	OpStructFieldOmitEmptyNumber             OpType = 257
-	OpStructEndNumber                        OpType = 258
-	OpStructEndOmitEmptyNumber               OpType = 259
-	OpStructFieldArray                       OpType = 260
-	OpStructFieldOmitEmptyArray              OpType = 261
-	OpStructEndArray                         OpType = 262
-	OpStructEndOmitEmptyArray                OpType = 263
-	OpStructFieldMap                         OpType = 264
-	OpStructFieldOmitEmptyMap                OpType = 265
-	OpStructEndMap                           OpType = 266
-	OpStructEndOmitEmptyMap                  OpType = 267
-	OpStructFieldSlice                       OpType = 268
-	OpStructFieldOmitEmptySlice              OpType = 269
-	OpStructEndSlice                         OpType = 270
-	OpStructEndOmitEmptySlice                OpType = 271
-	OpStructFieldStruct                      OpType = 272
-	OpStructFieldOmitEmptyStruct             OpType = 273
-	OpStructEndStruct                        OpType = 274
-	OpStructEndOmitEmptyStruct               OpType = 275
-	OpStructFieldMarshalJSON                 OpType = 276
-	OpStructFieldOmitEmptyMarshalJSON        OpType = 277
-	OpStructEndMarshalJSON                   OpType = 278
-	OpStructEndOmitEmptyMarshalJSON          OpType = 279
-	OpStructFieldMarshalText                 OpType = 280
-	OpStructFieldOmitEmptyMarshalText        OpType = 281
-	OpStructEndMarshalText                   OpType = 282
-	OpStructEndOmitEmptyMarshalText          OpType = 283
-	OpStructFieldIntString                   OpType = 284
-	OpStructFieldOmitEmptyIntString          OpType = 285
-	OpStructEndIntString                     OpType = 286
-	OpStructEndOmitEmptyIntString            OpType = 287
-	OpStructFieldUintString                  OpType = 288
-	OpStructFieldOmitEmptyUintString         OpType = 289
-	OpStructEndUintString                    OpType = 290
-	OpStructEndOmitEmptyUintString           OpType = 291
-	OpStructFieldFloat32String               OpType = 292
-	OpStructFieldOmitEmptyFloat32String      OpType = 293
-	OpStructEndFloat32String                 OpType = 294
-	OpStructEndOmitEmptyFloat32String        OpType = 295
-	OpStructFieldFloat64String               OpType = 296
-	OpStructFieldOmitEmptyFloat64String      OpType = 297
-	OpStructEndFloat64String                 OpType = 298
-	OpStructEndOmitEmptyFloat64String        OpType = 299
-	OpStructFieldBoolString                  OpType = 300
-	OpStructFieldOmitEmptyBoolString         OpType = 301
-	OpStructEndBoolString                    OpType = 302
-	OpStructEndOmitEmptyBoolString           OpType = 303
-	OpStructFieldStringString                OpType = 304
-	OpStructFieldOmitEmptyStringString       OpType = 305
-	OpStructEndStringString                  OpType = 306
-	OpStructEndOmitEmptyStringString         OpType = 307
-	OpStructFieldNumberString                OpType = 308
-	OpStructFieldOmitEmptyNumberString       OpType = 309
-	OpStructEndNumberString                  OpType = 310
-	OpStructEndOmitEmptyNumberString         OpType = 311
-	OpStructFieldIntPtr                      OpType = 312
-	OpStructFieldOmitEmptyIntPtr             OpType = 313
-	OpStructEndIntPtr                        OpType = 314
-	OpStructEndOmitEmptyIntPtr               OpType = 315
-	OpStructFieldUintPtr                     OpType = 316
-	OpStructFieldOmitEmptyUintPtr            OpType = 317
-	OpStructEndUintPtr                       OpType = 318
-	OpStructEndOmitEmptyUintPtr              OpType = 319
-	OpStructFieldFloat32Ptr                  OpType = 320
-	OpStructFieldOmitEmptyFloat32Ptr         OpType = 321
-	OpStructEndFloat32Ptr                    OpType = 322
-	OpStructEndOmitEmptyFloat32Ptr           OpType = 323
-	OpStructFieldFloat64Ptr                  OpType = 324
-	OpStructFieldOmitEmptyFloat64Ptr         OpType = 325
-	OpStructEndFloat64Ptr                    OpType = 326
-	OpStructEndOmitEmptyFloat64Ptr           OpType = 327
-	OpStructFieldBoolPtr                     OpType = 328
-	OpStructFieldOmitEmptyBoolPtr            OpType = 329
-	OpStructEndBoolPtr                       OpType = 330
-	OpStructEndOmitEmptyBoolPtr              OpType = 331
-	OpStructFieldStringPtr                   OpType = 332
-	OpStructFieldOmitEmptyStringPtr          OpType = 333
-	OpStructEndStringPtr                     OpType = 334
-	OpStructEndOmitEmptyStringPtr            OpType = 335
-	OpStructFieldBytesPtr                    OpType = 336
-	OpStructFieldOmitEmptyBytesPtr           OpType = 337
-	OpStructEndBytesPtr                      OpType = 338
-	OpStructEndOmitEmptyBytesPtr             OpType = 339
-	OpStructFieldNumberPtr                   OpType = 340
-	OpStructFieldOmitEmptyNumberPtr          OpType = 341
-	OpStructEndNumberPtr                     OpType = 342
-	OpStructEndOmitEmptyNumberPtr            OpType = 343
-	OpStructFieldArrayPtr                    OpType = 344
-	OpStructFieldOmitEmptyArrayPtr           OpType = 345
-	OpStructEndArrayPtr                      OpType = 346
-	OpStructEndOmitEmptyArrayPtr             OpType = 347
-	OpStructFieldMapPtr                      OpType = 348
-	OpStructFieldOmitEmptyMapPtr             OpType = 349
-	OpStructEndMapPtr                        OpType = 350
-	OpStructEndOmitEmptyMapPtr               OpType = 351
-	OpStructFieldSlicePtr                    OpType = 352
-	OpStructFieldOmitEmptySlicePtr           OpType = 353
-	OpStructEndSlicePtr                      OpType = 354
-	OpStructEndOmitEmptySlicePtr             OpType = 355
-	OpStructFieldMarshalJSONPtr              OpType = 356
-	OpStructFieldOmitEmptyMarshalJSONPtr     OpType = 357
-	OpStructEndMarshalJSONPtr                OpType = 358
-	OpStructEndOmitEmptyMarshalJSONPtr       OpType = 359
-	OpStructFieldMarshalTextPtr              OpType = 360
-	OpStructFieldOmitEmptyMarshalTextPtr     OpType = 361
-	OpStructEndMarshalTextPtr                OpType = 362
-	OpStructEndOmitEmptyMarshalTextPtr       OpType = 363
-	OpStructFieldInterfacePtr                OpType = 364
-	OpStructFieldOmitEmptyInterfacePtr       OpType = 365
-	OpStructEndInterfacePtr                  OpType = 366
-	OpStructEndOmitEmptyInterfacePtr         OpType = 367
-	OpStructFieldIntPtrString                OpType = 368
-	OpStructFieldOmitEmptyIntPtrString       OpType = 369
-	OpStructEndIntPtrString                  OpType = 370
-	OpStructEndOmitEmptyIntPtrString         OpType = 371
-	OpStructFieldUintPtrString               OpType = 372
-	OpStructFieldOmitEmptyUintPtrString      OpType = 373
-	OpStructEndUintPtrString                 OpType = 374
-	OpStructEndOmitEmptyUintPtrString        OpType = 375
-	OpStructFieldFloat32PtrString            OpType = 376
-	OpStructFieldOmitEmptyFloat32PtrString   OpType = 377
-	OpStructEndFloat32PtrString              OpType = 378
-	OpStructEndOmitEmptyFloat32PtrString     OpType = 379
-	OpStructFieldFloat64PtrString            OpType = 380
-	OpStructFieldOmitEmptyFloat64PtrString   OpType = 381
-	OpStructEndFloat64PtrString              OpType = 382
-	OpStructEndOmitEmptyFloat64PtrString     OpType = 383
-	OpStructFieldBoolPtrString               OpType = 384
-	OpStructFieldOmitEmptyBoolPtrString      OpType = 385
-	OpStructEndBoolPtrString                 OpType = 386
-	OpStructEndOmitEmptyBoolPtrString        OpType = 387
-	OpStructFieldStringPtrString             OpType = 388
-	OpStructFieldOmitEmptyStringPtrString    OpType = 389
-	OpStructEndStringPtrString               OpType = 390
-	OpStructEndOmitEmptyStringPtrString      OpType = 391
-	OpStructFieldNumberPtrString             OpType = 392
-	OpStructFieldOmitEmptyNumberPtrString    OpType = 393
-	OpStructEndNumberPtrString               OpType = 394
-	OpStructEndOmitEmptyNumberPtrString      OpType = 395
-	OpStructField                            OpType = 396
-	OpStructFieldOmitEmpty                   OpType = 397
-	OpStructEnd                              OpType = 398
-	OpStructEndOmitEmpty                     OpType = 399
+	OpRedact                                 OpType = 14
+	OpStream                                 OpType = 15
+	OpStreamPtr                              OpType = 16
+	OpInt                                    OpType = 17
+	OpUint                                   OpType = 18
+	OpFloat32                                OpType = 19
+	OpFloat64                                OpType = 20
+	OpBool                                   OpType = 21
+	OpString                                 OpType = 22
+	OpBytes                                  OpType = 23
+	OpNumber                                 OpType = 24
+	OpArray                                  OpType = 25
+	OpMap                                    OpType = 26
+	OpSlice                                  OpType = 27
+	OpStruct                                 OpType = 28
+	OpMarshalJSON                            OpType = 29
+	OpMarshalText                            OpType = 30
+	OpIntString                              OpType = 31
+	OpUintString                             OpType = 32
+	OpFloat32String                          OpType = 33
+	OpFloat64String                          OpType = 34
+	OpBoolString                             OpType = 35
+	OpStringString                           OpType = 36
+	OpNumberString                           OpType = 37
+	OpIntPtr                                 OpType = 38
+	OpUintPtr                                OpType = 39
+	OpFloat32Ptr                             OpType = 40
+	OpFloat64Ptr                             OpType = 41
+	OpBoolPtr                                OpType = 42
+	OpStringPtr                              OpType = 43
+	OpBytesPtr                               OpType = 44
+	OpNumberPtr                              OpType = 45
+	OpArrayPtr                               OpType = 46
+	OpMapPtr                                 OpType = 47
+	OpSlicePtr                               OpType = 48
+	OpMarshalJSONPtr                         OpType = 49
+	OpMarshalTextPtr                         OpType = 50
+	OpInterfacePtr                           OpType = 51
+	OpIntPtrString                           OpType = 52
+	OpUintPtrString                          OpType = 53
+	OpFloat32PtrString                       OpType = 54
+	OpFloat64PtrString                       OpType = 55
+	OpBoolPtrString                          OpType = 56
+	OpStringPtrString                        OpType = 57
+	OpNumberPtrString                        OpType = 58
+	OpStructHeadInt                          OpType = 59
+	OpStructHeadOmitEmptyInt                 OpType = 60
+	OpStructPtrHeadInt                       OpType = 61
+	OpStructPtrHeadOmitEmptyInt              OpType = 62
+	OpStructHeadUint                         OpType = 63
+	OpStructHeadOmitEmptyUint                OpType = 64
+	OpStructPtrHeadUint                      OpType = 65
+	OpStructPtrHeadOmitEmptyUint             OpType = 66
+	OpStructHeadFloat32                      OpType = 67
+	OpStructHeadOmitEmptyFloat32             OpType = 68
+	OpStructPtrHeadFloat32                   OpType = 69
+	OpStructPtrHeadOmitEmptyFloat32          OpType = 70
+	OpStructHeadFloat64                      OpType = 71
+	OpStructHeadOmitEmptyFloat64             OpType = 72
+	OpStructPtrHeadFloat64                   OpType = 73
+	OpStructPtrHeadOmitEmptyFloat64          OpType = 74
+	OpStructHeadBool                         OpType = 75
+	OpStructHeadOmitEmptyBool                OpType = 76
+	OpStructPtrHeadBool                      OpType = 77
+	OpStructPtrHeadOmitEmptyBool             OpType = 78
+	OpStructHeadString                       OpType = 79
+	OpStructHeadOmitEmptyString              OpType = 80
+	OpStructPtrHeadString                    OpType = 81
+	OpStructPtrHeadOmitEmptyString           OpType = 82
+	OpStructHeadBytes                        OpType = 83
+	OpStructHeadOmitEmptyBytes               OpType = 84
+	OpStructPtrHeadBytes                     OpType = 85
+	OpStructPtrHeadOmitEmptyBytes            OpType = 86
+	OpStructHeadNumber                       OpType = 87
+	OpStructHeadOmitEmptyNumber              OpType = 88
+	OpStructPtrHeadNumber                    OpType = 89
+	OpStructPtrHeadOmitEmptyNumber           OpType = 90
+	OpStructHeadArray                        OpType = 91
+	OpStructHeadOmitEmptyArray               OpType = 92
+	OpStructPtrHeadArray                     OpType = 93
+	OpStructPtrHeadOmitEmptyArray            OpType = 94
+	OpStructHeadMap                          OpType = 95
+	OpStructHeadOmitEmptyMap                 OpType = 96
+	OpStructPtrHeadMap                       OpType = 97
+	OpStructPtrHeadOmitEmptyMap              OpType = 98
+	OpStructHeadSlice                        OpType = 99
+	OpStructHeadOmitEmptySlice               OpType = 100
+	OpStructPtrHeadSlice                     OpType = 101
+	OpStructPtrHeadOmitEmptySlice            OpType = 102
+	OpStructHeadStruct                       OpType = 103
+	OpStructHeadOmitEmptyStruct              OpType = 104
+	OpStructPtrHeadStruct                    OpType = 105
+	OpStructPtrHeadOmitEmptyStruct           OpType = 106
+	OpStructHeadMarshalJSON                  OpType = 107
+	OpStructHeadOmitEmptyMarshalJSON         OpType = 108
+	OpStructPtrHeadMarshalJSON               OpType = 109
+	OpStructPtrHeadOmitEmptyMarshalJSON      OpType = 110
+	OpStructHeadMarshalText                  OpType = 111
+	OpStructHeadOmitEmptyMarshalText         OpType = 112
+	OpStructPtrHeadMarshalText               OpType = 113
+	OpStructPtrHeadOmitEmptyMarshalText      OpType = 114
+	OpStructHeadIntString                    OpType = 115
+	OpStructHeadOmitEmptyIntString           OpType = 116
+	OpStructPtrHeadIntString                 OpType = 117
+	OpStructPtrHeadOmitEmptyIntString        OpType = 118
+	OpStructHeadUintString                   OpType = 119
+	OpStructHeadOmitEmptyUintString          OpType = 120
+	OpStructPtrHeadUintString                OpType = 121
+	OpStructPtrHeadOmitEmptyUintString       OpType = 122
+	OpStructHeadFloat32String                OpType = 123
+	OpStructHeadOmitEmptyFloat32String       OpType = 124
+	OpStructPtrHeadFloat32String             OpType = 125
+	OpStructPtrHeadOmitEmptyFloat32String    OpType = 126
+	OpStructHeadFloat64String                OpType = 127
+	OpStructHeadOmitEmptyFloat64String       OpType = 128
+	OpStructPtrHeadFloat64String             OpType = 129
+	OpStructPtrHeadOmitEmptyFloat64String    OpType = 130
+	OpStructHeadBoolString                   OpType = 131
+	OpStructHeadOmitEmptyBoolString          OpType = 132
+	OpStructPtrHeadBoolString                OpType = 133
+	OpStructPtrHeadOmitEmptyBoolString       OpType = 134
+	OpStructHeadStringString                 OpType = 135
+	OpStructHeadOmitEmptyStringString        OpType = 136
+	OpStructPtrHeadStringString              OpType = 137
+	OpStructPtrHeadOmitEmptyStringString     OpType = 138
+	OpStructHeadNumberString                 OpType = 139
+	OpStructHeadOmitEmptyNumberString        OpType = 140
+	OpStructPtrHeadNumberString              OpType = 141
+	OpStructPtrHeadOmitEmptyNumberString     OpType = 142
+	OpStructHeadIntPtr                       OpType = 143
+	OpStructHeadOmitEmptyIntPtr              OpType = 144
+	OpStructPtrHeadIntPtr                    OpType = 145
+	OpStructPtrHeadOmitEmptyIntPtr           OpType = 146
+	OpStructHeadUintPtr                      OpType = 147
+	OpStructHeadOmitEmptyUintPtr             OpType = 148
+	OpStructPtrHeadUintPtr                   OpType = 149
+	OpStructPtrHeadOmitEmptyUintPtr          OpType = 150
+	OpStructHeadFloat32Ptr                   OpType = 151
+	OpStructHeadOmitEmptyFloat32Ptr          OpType = 152
+	OpStructPtrHeadFloat32Ptr                OpType = 153
+	OpStructPtrHeadOmitEmptyFloat32Ptr       OpType = 154
+	OpStructHeadFloat64Ptr                   OpType = 155
+	OpStructHeadOmitEmptyFloat64Ptr          OpType = 156
+	OpStructPtrHeadFloat64Ptr                OpType = 157
+	OpStructPtrHeadOmitEmptyFloat64Ptr       OpType = 158
+	OpStructHeadBoolPtr                      OpType = 159
+	OpStructHeadOmitEmptyBoolPtr             OpType = 160
+	OpStructPtrHeadBoolPtr                   OpType = 161
+	OpStructPtrHeadOmitEmptyBoolPtr          OpType = 162
+	OpStructHeadStringPtr                    OpType = 163
+	OpStructHeadOmitEmptyStringPtr           OpType = 164
+	OpStructPtrHeadStringPtr                 OpType = 165
+	OpStructPtrHeadOmitEmptyStringPtr        OpType = 166
+	OpStructHeadBytesPtr                     OpType = 167
+	OpStructHeadOmitEmptyBytesPtr            OpType = 168
+	OpStructPtrHeadBytesPtr                  OpType = 169
+	OpStructPtrHeadOmitEmptyBytesPtr         OpType = 170
+	OpStructHeadNumberPtr                    OpType = 171
+	OpStructHeadOmitEmptyNumberPtr           OpType = 172
+	OpStructPtrHeadNumberPtr                 OpType = 173
+	OpStructPtrHeadOmitEmptyNumberPtr        OpType = 174
+	OpStructHeadArrayPtr                     OpType = 175
+	OpStructHeadOmitEmptyArrayPtr            OpType = 176
+	OpStructPtrHeadArrayPtr                  OpType = 177
+	OpStructPtrHeadOmitEmptyArrayPtr         OpType = 178
+	OpStructHeadMapPtr                       OpType = 179
+	OpStructHeadOmitEmptyMapPtr              OpType = 180
+	OpStructPtrHeadMapPtr                    OpType = 181
+	OpStructPtrHeadOmitEmptyMapPtr           OpType = 182
+	OpStructHeadSlicePtr                     OpType = 183
+	OpStructHeadOmitEmptySlicePtr            OpType = 184
+	OpStructPtrHeadSlicePtr                  OpType = 185
+	OpStructPtrHeadOmitEmptySlicePtr         OpType = 186
+	OpStructHeadMarshalJSONPtr               OpType = 187
+	OpStructHeadOmitEmptyMarshalJSONPtr      OpType = 188
+	OpStructPtrHeadMarshalJSONPtr            OpType = 189
+	OpStructPtrHeadOmitEmptyMarshalJSONPtr   OpType = 190
+	OpStructHeadMarshalTextPtr               OpType = 191
+	OpStructHeadOmitEmptyMarshalTextPtr      OpType = 192
+	OpStructPtrHeadMarshalTextPtr            OpType = 193
+	OpStructPtrHeadOmitEmptyMarshalTextPtr   OpType = 194
+	OpStructHeadInterfacePtr                 OpType = 195
+	OpStructHeadOmitEmptyInterfacePtr        OpType = 196
+	OpStructPtrHeadInterfacePtr              OpType = 197
+	OpStructPtrHeadOmitEmptyInterfacePtr     OpType = 198
+	OpStructHeadIntPtrString                 OpType = 199
+	OpStructHeadOmitEmptyIntPtrString        OpType = 200
+	OpStructPtrHeadIntPtrString              OpType = 201
+	OpStructPtrHeadOmitEmptyIntPtrString     OpType = 202
+	OpStructHeadUintPtrString                OpType = 203
+	OpStructHeadOmitEmptyUintPtrString       OpType = 204
+	OpStructPtrHeadUintPtrString             OpType = 205
+	OpStructPtrHeadOmitEmptyUintPtrString    OpType = 206
+	OpStructHeadFloat32PtrString             OpType = 207
+	OpStructHeadOmitEmptyFloat32PtrString    OpType = 208
+	OpStructPtrHeadFloat32PtrString          OpType = 209
+	OpStructPtrHeadOmitEmptyFloat32PtrString OpType = 210
+	OpStructHeadFloat64PtrString             OpType = 211
+	OpStructHeadOmitEmptyFloat64PtrString    OpType = 212
+	OpStructPtrHeadFloat64PtrString          OpType = 213
+	OpStructPtrHeadOmitEmptyFloat64PtrString OpType = 214
+	OpStructHeadBoolPtrString                OpType = 215
+	OpStructHeadOmitEmptyBoolPtrString       OpType = 216
+	OpStructPtrHeadBoolPtrString             OpType = 217
+	OpStructPtrHeadOmitEmptyBoolPtrString    OpType = 218
+	OpStructHeadStringPtrString              OpType = 219
+	OpStructHeadOmitEmptyStringPtrString     OpType = 220
+	OpStructPtrHeadStringPtrString           OpType = 221
+	OpStructPtrHeadOmitEmptyStringPtrString  OpType = 222
+	OpStructHeadNumberPtrString              OpType = 223
+	OpStructHeadOmitEmptyNumberPtrString     OpType = 224
+	OpStructPtrHeadNumberPtrString           OpType = 225
+	OpStructPtrHeadOmitEmptyNumberPtrString  OpType = 226
+	OpStructHead                             OpType = 227
+	OpStructHeadOmitEmpty                    OpType = 228
+	OpStructPtrHead                          OpType = 229
+	OpStructPtrHeadOmitEmpty                 OpType = 230
+	OpStructFieldInt                         OpType = 231
+	OpStructFieldOmitEmptyInt                OpType = 232
+	OpStructEndInt                           OpType = 233
+	OpStructEndOmitEmptyInt                  OpType = 234
+	OpStructFieldUint                        OpType = 235
+	OpStructFieldOmitEmptyUint               OpType = 236
+	OpStructEndUint                          OpType = 237
+	OpStructEndOmitEmptyUint                 OpType = 238
+	OpStructFieldFloat32                     OpType = 239
+	OpStructFieldOmitEmptyFloat32            OpType = 240
+	OpStructEndFloat32                       OpType = 241
+	OpStructEndOmitEmptyFloat32              OpType = 242
+	OpStructFieldFloat64                     OpType = 243
+	OpStructFieldOmitEmptyFloat64            OpType = 244
+	OpStructEndFloat64                       OpType = 245
+	OpStructEndOmitEmptyFloat64              OpType = 246
+	OpStructFieldBool                        OpType = 247
+	OpStructFieldOmitEmptyBool               OpType = 248
+	OpStructEndBool                          OpType = 249
+	OpStructEndOmitEmptyBool                 OpType = 250
+	OpStructFieldString                      OpType = 251
+	OpStructFieldOmitEmptyString             OpType = 252
+	OpStructEndString                        OpType = 253
+	OpStructEndOmitEmptyString               OpType = 254
+	OpStructFieldBytes                       OpType = 255
+	OpStructFieldOmitEmptyBytes              OpType = 256
+	OpStructEndBytes                         OpType = 257
+	OpStructEndOmitEmptyBytes                OpType = 258
+	OpStructFieldNumber                      OpType = 259
+	OpStructFieldOmitEmptyNumber             OpType = 260
+	OpStructEndNumber                        OpType = 261
+	OpStructEndOmitEmptyNumber               OpType = 262
+	OpStructFieldArray                       OpType = 263
+	OpStructFieldOmitEmptyArray              OpType = 264
+	OpStructEndArray                         OpType = 265
+	OpStructEndOmitEmptyArray                OpType = 266
+	OpStructFieldMap                         OpType = 267
+	OpStructFieldOmitEmptyMap                OpType = 268
+	OpStructEndMap                           OpType = 269
+	OpStructEndOmitEmptyMap                  OpType = 270
+	OpStructFieldSlice                       OpType = 271
+	OpStructFieldOmitEmptySlice              OpType = 272
+	OpStructEndSlice                         OpType = 273
+	OpStructEndOmitEmptySlice                OpType = 274
+	OpStructFieldStruct                      OpType = 275
+	OpStructFieldOmitEmptyStruct             OpType = 276
+	OpStructEndStruct                        OpType = 277
+	OpStructEndOmitEmptyStruct               OpType = 278
+	OpStructFieldMarshalJSON                 OpType = 279
+	OpStructFieldOmitEmptyMarshalJSON        OpType = 280
+	OpStructEndMarshalJSON                   OpType = 281
+	OpStructEndOmitEmptyMarshalJSON          OpType = 282
+	OpStructFieldMarshalText                 OpType = 283
+	OpStructFieldOmitEmptyMarshalText        OpType = 284
+	OpStructEndMarshalText                   OpType = 285
+	OpStructEndOmitEmptyMarshalText          OpType = 286
+	OpStructFieldIntString                   OpType = 287
+	OpStructFieldOmitEmptyIntString          OpType = 288
+	OpStructEndIntString                     OpType = 289
+	OpStructEndOmitEmptyIntString            OpType = 290
+	OpStructFieldUintString                  OpType = 291
+	OpStructFieldOmitEmptyUintString         OpType = 292
+	OpStructEndUintString                    OpType = 293
+	OpStructEndOmitEmptyUintString           OpType = 294
+	OpStructFieldFloat32String               OpType = 295
+	OpStructFieldOmitEmptyFloat32String      OpType = 296
+	OpStructEndFloat32String                 OpType = 297
+	OpStructEndOmitEmptyFloat32String        OpType = 298
+	OpStructFieldFloat64String               OpType = 299
+	OpStructFieldOmitEmptyFloat64String      OpType = 300
+	OpStructEndFloat64String                 OpType = 301
+	OpStructEndOmitEmptyFloat64String        OpType = 302
+	OpStructFieldBoolString                  OpType = 303
+	OpStructFieldOmitEmptyBoolString         OpType = 304
+	OpStructEndBoolString                    OpType = 305
+	OpStructEndOmitEmptyBoolString           OpType = 306
+	OpStructFieldStringString                OpType = 307
+	OpStructFieldOmitEmptyStringString       OpType = 308
+	OpStructEndStringString                  OpType = 309
+	OpStructEndOmitEmptyStringString         OpType = 310
+	OpStructFieldNumberString                OpType = 311
+	OpStructFieldOmitEmptyNumberString       OpType = 312
+	OpStructEndNumberString                  OpType = 313
+	OpStructEndOmitEmptyNumberString         OpType = 314
+	OpStructFieldIntPtr                      OpType = 315
+	OpStructFieldOmitEmptyIntPtr             OpType = 316
+	OpStructEndIntPtr                        OpType = 317
+	OpStructEndOmitEmptyIntPtr               OpType = 318
+	OpStructFieldUintPtr                     OpType = 319
+	OpStructFieldOmitEmptyUintPtr            OpType = 320
+	OpStructEndUintPtr                       OpType = 321
+	OpStructEndOmitEmptyUintPtr              OpType = 322
+	OpStructFieldFloat32Ptr                  OpType = 323
+	OpStructFieldOmitEmptyFloat32Ptr         OpType = 324
+	OpStructEndFloat32Ptr                    OpType = 325
+	OpStructEndOmitEmptyFloat32Ptr           OpType = 326
+	OpStructFieldFloat64Ptr                  OpType = 327
+	OpStructFieldOmitEmptyFloat64Ptr         OpType = 328
+	OpStructEndFloat64Ptr                    OpType = 329
+	OpStructEndOmitEmptyFloat64Ptr           OpType = 330
+	OpStructFieldBoolPtr                     OpType = 331
+	OpStructFieldOmitEmptyBoolPtr            OpType = 332
+	OpStructEndBoolPtr                       OpType = 333
+	OpStructEndOmitEmptyBoolPtr              OpType = 334
+	OpStructFieldStringPtr                   OpType = 335
+	OpStructFieldOmitEmptyStringPtr          OpType = 336
+	OpStructEndStringPtr                     OpType = 337
+	OpStructEndOmitEmptyStringPtr            OpType = 338
+	OpStructFieldBytesPtr                    OpType = 339
+	OpStructFieldOmitEmptyBytesPtr           OpType = 340
+	OpStructEndBytesPtr                      OpType = 341
+	OpStructEndOmitEmptyBytesPtr             OpType = 342
+	OpStructFieldNumberPtr                   OpType = 343
+	OpStructFieldOmitEmptyNumberPtr          OpType = 344
+	OpStructEndNumberPtr                     OpType = 345
+	OpStructEndOmitEmptyNumberPtr            OpType = 346
+	OpStructFieldArrayPtr                    OpType = 347
+	OpStructFieldOmitEmptyArrayPtr           OpType = 348
+	OpStructEndArrayPtr                      OpType = 349
+	OpStructEndOmitEmptyArrayPtr             OpType = 350
+	OpStructFieldMapPtr                      OpType = 351
+	OpStructFieldOmitEmptyMapPtr             OpType = 352
+	OpStructEndMapPtr                        OpType = 353
+	OpStructEndOmitEmptyMapPtr               OpType = 354
+	OpStructFieldSlicePtr                    OpType = 355
+	OpStructFieldOmitEmptySlicePtr           OpType = 356
+	OpStructEndSlicePtr                      OpType = 357
+	OpStructEndOmitEmptySlicePtr             OpType = 358
+	OpStructFieldMarshalJSONPtr              OpType = 359
+	OpStructFieldOmitEmptyMarshalJSONPtr     OpType = 360
+	OpStructEndMarshalJSONPtr                OpType = 361
+	OpStructEndOmitEmptyMarshalJSONPtr       OpType = 362
+	OpStructFieldMarshalTextPtr              OpType = 363
+	OpStructFieldOmitEmptyMarshalTextPtr     OpType = 364
+	OpStructEndMarshalTextPtr                OpType = 365
+	OpStructEndOmitEmptyMarshalTextPtr       OpType = 366
+	OpStructFieldInterfacePtr                OpType = 367
+	OpStructFieldOmitEmptyInterfacePtr       OpType = 368
+	OpStructEndInterfacePtr                  OpType = 369
+	OpStructEndOmitEmptyInterfacePtr         OpType = 370
+	OpStructFieldIntPtrString                OpType = 371
+	OpStructFieldOmitEmptyIntPtrString       OpType = 372
+	OpStructEndIntPtrString                  OpType = 373
+	OpStructEndOmitEmptyIntPtrString         OpType = 374
+	OpStructFieldUintPtrString               OpType = 375
+	OpStructFieldOmitEmptyUintPtrString      OpType = 376
+	OpStructEndUintPtrString                 OpType = 377
+	OpStructEndOmitEmptyUintPtrString        OpType = 378
+	OpStructFieldFloat32PtrString            OpType = 379
+	OpStructFieldOmitEmptyFloat32PtrString   OpType = 380
+	OpStructEndFloat32PtrString              OpType = 381
+	OpStructEndOmitEmptyFloat32PtrString     OpType = 382
+	OpStructFieldFloat64PtrString            OpType = 383
+	OpStructFieldOmitEmptyFloat64PtrString   OpType = 384
+	OpStructEndFloat64PtrString              OpType = 385
+	OpStructEndOmitEmptyFloat64PtrString     OpType = 386
+	OpStructFieldBoolPtrString               OpType = 387
+	OpStructFieldOmitEmptyBoolPtrString      OpType = 388
+	OpStructEndBoolPtrString                 OpType = 389
+	OpStructEndOmitEmptyBoolPtrString        OpType = 390
+	OpStructFieldStringPtrString             OpType = 391
+	OpStructFieldOmitEmptyStringPtrString    OpType = 392
+	OpStructEndStringPtrString               OpType = 393
+	OpStructEndOmitEmptyStringPtrString      OpType = 394
+	OpStructFieldNumberPtrString             OpType = 395
+	OpStructFieldOmitEmptyNumberPtrString    OpType = 396
+	OpStructEndNumberPtrString               OpType = 397
+	OpStructEndOmitEmptyNumberPtrString      OpType = 398
+	OpStructField                            OpType = 399
+	OpStructFieldOmitEmpty                   OpType = 400
+	OpStructEnd                              OpType = 401
+	OpStructEndOmitEmpty                     OpType = 402
 )
 
 func (t OpType) String() string {
-	if int(t) >= 400 {
+	if int(t) >= 403 {
 		return ""
 	}
 	return opTypeStrings[int(t)]
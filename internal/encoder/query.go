@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 var (
@@ -14,7 +15,10 @@ var (
 type FieldQuery struct {
 	Name   string
 	Fields []*FieldQuery
-	hash   string
+	// Exclude reports whether the query excludes the field instead of selecting it.
+	// The name of the excluded field can be a dot separated path to a nested field.
+	Exclude bool
+	hash    string
 }
 
 func (q *FieldQuery) Hash() string {
@@ -27,6 +31,9 @@ func (q *FieldQuery) Hash() string {
 }
 
 func (q *FieldQuery) MarshalJSON() ([]byte, error) {
+	if q.Exclude {
+		return Marshal(map[string]string{excludeFieldKey: q.Name})
+	}
 	if q.Name != "" {
 		if len(q.Fields) > 0 {
 			return Marshal(map[string][]*FieldQuery{q.Name: q.Fields})
@@ -36,6 +43,42 @@ func (q *FieldQuery) MarshalJSON() ([]byte, error) {
 	return Marshal(q.Fields)
 }
 
+// SubQuery returns whether the field with the name is kept by the query,
+// and the query to apply to the value of the field if it's necessary.
+// If the query has no selecting fields, all the fields except the excluded ones are kept.
+func (q *FieldQuery) SubQuery(name string) (*FieldQuery, bool) {
+	var (
+		fields    []*FieldQuery
+		selected  bool
+		inclusive bool
+	)
+	for _, field := range q.Fields {
+		if field.Exclude {
+			head, rest, nested := strings.Cut(field.Name, ".")
+			if head != name {
+				continue
+			}
+			if !nested {
+				return nil, false
+			}
+			fields = append(fields, &FieldQuery{Name: rest, Exclude: true})
+			continue
+		}
+		inclusive = true
+		if field.Name == name {
+			selected = true
+			fields = append(fields, field.Fields...)
+		}
+	}
+	if inclusive && !selected {
+		return nil, false
+	}
+	if len(fields) == 0 {
+		return nil, true
+	}
+	return &FieldQuery{Name: name, Fields: fields}, true
+}
+
 func (q *FieldQuery) QueryString() (FieldQueryString, error) {
 	b, err := Marshal(q)
 	if err != nil {
@@ -46,6 +89,10 @@ func (q *FieldQuery) QueryString() (FieldQueryString, error) {
 
 type FieldQueryString string
 
+// excludeFieldKey is the key of the object that marks the excluded field, such as {"-":"password"}.
+// Its value is a string instead of the array of the sub fields, so it's distinguished from the field named "-".
+const excludeFieldKey = "-"
+
 func (s FieldQueryString) Build() (*FieldQuery, error) {
 	var query interface{}
 	if err := Unmarshal([]byte(s), &query); err != nil {
@@ -106,7 +153,13 @@ func (s FieldQueryString) buildMap(v reflect.Value) (*FieldQuery, error) {
 		return nil, fmt.Errorf("failed to build field query. invalid object key type")
 	}
 	name := key.String()
-	def, err := s.build(v.MapIndex(key))
+	value := v.MapIndex(key)
+	if name == excludeFieldKey {
+		if excluded, ok := value.Interface().(string); ok {
+			return &FieldQuery{Name: excluded, Exclude: true}, nil
+		}
+	}
+	def, err := s.build(value)
 	if err != nil {
 		return nil, err
 	}
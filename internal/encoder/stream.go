@@ -0,0 +1,154 @@
+package encoder
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+var (
+	boolType  = reflect.TypeOf(true)
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// isStreamType reports whether typ is encoded as JSON array of the elements produced by it.
+// They are the receivable channel, the iterator `func(yield func(T) bool)`,
+// and the iterator that reports the error `func(yield func(T) bool) error`.
+func isStreamType(typ *runtime.Type) bool {
+	switch typ.Kind() {
+	case reflect.Chan:
+		return typ.ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		if typ.NumIn() != 1 || typ.NumOut() > 1 || typ.IsVariadic() {
+			return false
+		}
+		if typ.NumOut() == 1 && typ.Out(0) != errorType {
+			return false
+		}
+		yield := typ.In(0)
+		return yield.Kind() == reflect.Func &&
+			yield.NumIn() == 1 && yield.NumOut() == 1 && !yield.IsVariadic() &&
+			yield.Out(0) == boolType
+	}
+	return false
+}
+
+// StreamRunner runs the opcodes of the stream element.
+type StreamRunner func(*RuntimeContext, []byte, *OpcodeSet) ([]byte, error)
+
+// AppendStream appends the elements produced by the channel or the iterator v as JSON array.
+// The elements are encoded one by one with run, and the buffer is flushed after each element.
+func AppendStream(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}, run StreamRunner, indent bool) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return AppendNull(ctx, b), nil
+	}
+	s := &streamEncoder{ctx: ctx, code: code, run: run, indent: indent, buf: b}
+	switch rv.Kind() {
+	case reflect.Chan:
+		for {
+			elem, ok := rv.Recv()
+			if !ok {
+				break
+			}
+			if err := s.appendElem(elem.Interface()); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Func:
+		yield := reflect.MakeFunc(rv.Type().In(0), func(args []reflect.Value) []reflect.Value {
+			err := s.appendElem(args[0].Interface())
+			if err != nil {
+				s.err = err
+			}
+			return []reflect.Value{reflect.ValueOf(err == nil)}
+		})
+		out := rv.Call([]reflect.Value{yield})
+		if s.err != nil {
+			return nil, s.err
+		}
+		if len(out) == 1 && !out[0].IsNil() {
+			return nil, errors.ErrMarshaler(rv.Type(), out[0].Interface().(error), "iterator")
+		}
+	}
+	return s.end(), nil
+}
+
+type streamEncoder struct {
+	ctx    *RuntimeContext
+	code   *Opcode
+	run    StreamRunner
+	indent bool
+	buf    []byte
+	length int
+	err    error
+}
+
+func (s *streamEncoder) appendElem(v interface{}) error {
+	b := s.buf
+	if s.length == 0 {
+		b = append(b, '[')
+		if s.indent {
+			b = append(b, '\n')
+		}
+	}
+	if s.indent {
+		b = AppendIndent(s.ctx, b, s.code.Indent+1)
+	}
+	b, err := s.appendValue(b, v)
+	if err != nil {
+		return err
+	}
+	s.length++
+	b, err = s.ctx.Flush(b)
+	if err != nil {
+		return err
+	}
+	s.buf = b
+	return nil
+}
+
+// appendValue encodes v with the new context, because the opcodes of the stream are still running with ctx.
+func (s *streamEncoder) appendValue(b []byte, v interface{}) ([]byte, error) {
+	if v == nil {
+		b = AppendNull(s.ctx, b)
+		if s.indent {
+			return AppendCommaIndent(s.ctx, b), nil
+		}
+		return AppendComma(s.ctx, b), nil
+	}
+	ctx := TakeRuntimeContext()
+	defer ReleaseRuntimeContext(ctx)
+
+	*ctx.Option = *s.ctx.Option
+	ctx.Prefix = s.ctx.Prefix
+	ctx.IndentStr = s.ctx.IndentStr
+	ctx.Writer = s.ctx.Writer
+
+	header := (*emptyInterface)(unsafe.Pointer(&v))
+	codeSet, err := CompileToGetCodeSet(ctx, uintptr(unsafe.Pointer(header.typ)))
+	if err != nil {
+		return nil, err
+	}
+	ctx.Init(uintptr(header.ptr), codeSet.CodeLength)
+	ctx.KeepRefs = append(ctx.KeepRefs, header.ptr)
+	ctx.BaseIndent = s.ctx.BaseIndent + s.code.Indent + 1
+	ctx.flushSuspended = s.ctx.flushSuspended
+	return s.run(ctx, b, codeSet)
+}
+
+func (s *streamEncoder) end() []byte {
+	b := s.buf
+	if s.length == 0 {
+		return append(b, '[', ']')
+	}
+	if s.indent {
+		b = append(b[:len(b)-2], '\n')
+		b = AppendIndent(s.ctx, b, s.code.Indent)
+		return append(b, ']')
+	}
+	b[len(b)-1] = ']'
+	return b
+}
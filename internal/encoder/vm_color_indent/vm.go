@@ -237,6 +237,35 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			storeIndent(ctxptr, end, uintptr(oldBaseIndent))
 			code = c
 			recursiveLevel++
+		case encoder.OpStreamPtr:
+			p := load(ctxptr, code.Idx)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			store(ctxptr, code.Idx, ptrToPtr(p))
+			fallthrough
+		case encoder.OpStream:
+			p := load(ctxptr, code.Idx)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			if (code.Flags & encoder.IndirectFlags) != 0 {
+				p = ptrToPtr(p)
+			}
+			bb, err := appendStream(ctx, code, b, ptrToInterface(code, p))
+			if err != nil {
+				return nil, err
+			}
+			b = appendComma(ctx, bb)
+			code = code.Next
+		case encoder.OpRedact:
+			b = appendString(ctx, b, ctx.Option.RedactMask)
+			b = appendComma(ctx, b)
+			code = code.Next
 		case encoder.OpInterfaceEnd:
 			recursiveLevel--
 
@@ -329,6 +358,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpSliceElem:
+			bb, err := ctx.FlushIfExceeded(b)
+			if err != nil {
+				return nil, err
+			}
+			b = bb
 			idx := load(ctxptr, code.ElemIdx)
 			length := load(ctxptr, code.Length)
 			idx++
@@ -369,6 +403,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpArrayElem:
+			bb, err := ctx.FlushIfExceeded(b)
+			if err != nil {
+				return nil, err
+			}
+			b = bb
 			idx := load(ctxptr, code.ElemIdx)
 			idx++
 			if idx < uintptr(code.Length) {
@@ -416,6 +455,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			} else {
 				mapCtx.Start = len(b)
 				mapCtx.First = len(b)
+				ctx.SuspendFlush()
 			}
 			key := mapiterkey(&mapCtx.Iter)
 			store(ctxptr, code.Next.Idx, uintptr(key))
@@ -426,7 +466,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			idx++
 			if (ctx.Option.Flag & encoder.UnorderedMapOption) != 0 {
 				if idx < mapCtx.Len {
-					b = appendMapKeyIndent(ctx, code, b)
+					bb, err := ctx.FlushIfExceeded(b)
+					if err != nil {
+						return nil, err
+					}
+					b = appendMapKeyIndent(ctx, code, bb)
 					mapCtx.Idx = int(idx)
 					key := mapiterkey(&mapCtx.Iter)
 					store(ctxptr, code.Next.Idx, uintptr(key))
@@ -473,6 +517,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			b = append(b, buf...)
 			mapCtx.Buf = buf
 			encoder.ReleaseMapContext(mapCtx)
+			ctx.ResumeFlush()
 			code = code.Next
 		case encoder.OpRecursivePtr:
 			p := load(ctxptr, code.Idx)
@@ -582,7 +627,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			p += uintptr(code.Offset)
-			if p == 0 || (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) {
+			if p == 0 || (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) || encoder.IsEmptyRedactValue(code.Next, p) {
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
@@ -3267,7 +3312,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructFieldOmitEmpty:
 			p := load(ctxptr, code.Idx)
 			p += uintptr(code.Offset)
-			if ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0 {
+			if (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) || encoder.IsEmptyRedactValue(code.Next, p) {
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
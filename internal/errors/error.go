@@ -100,6 +100,19 @@ func (e *UnsupportedTypeError) Error() string {
 	return fmt.Sprintf("json: unsupported type: %s", e.Type)
 }
 
+// A CanceledError is returned when decoding is aborted because the context is done.
+type CanceledError struct {
+	Err    error // the error returned by the context
+	Offset int64 // decoding was aborted after reading Offset bytes
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("json: decoding aborted at offset %d: %s", e.Offset, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *CanceledError) Unwrap() error { return e.Err }
+
 type UnsupportedValueError struct {
 	Value reflect.Value
 	Str   string
@@ -113,6 +126,10 @@ func ErrSyntax(msg string, offset int64) *SyntaxError {
 	return &SyntaxError{msg: msg, Offset: offset}
 }
 
+func ErrCanceled(err error, offset int64) *CanceledError {
+	return &CanceledError{Err: err, Offset: offset}
+}
+
 func ErrMarshaler(typ reflect.Type, err error, msg string) *MarshalerError {
 	return &MarshalerError{
 		Type:       typ,
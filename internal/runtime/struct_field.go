@@ -34,6 +34,8 @@ type StructTag struct {
 	IsTaggedKey bool
 	IsOmitEmpty bool
 	IsString    bool
+	IsRedact    bool
+	Groups      []string
 	Field       reflect.StructField
 }
 
@@ -65,6 +67,8 @@ func isValidTag(s string) bool {
 	return true
 }
 
+const groupsTagOptionPrefix = "groups="
+
 func StructTagFromField(field reflect.StructField) *StructTag {
 	keyName := field.Name
 	tag := getTag(field)
@@ -84,6 +88,12 @@ func StructTagFromField(field reflect.StructField) *StructTag {
 				st.IsOmitEmpty = true
 			case "string":
 				st.IsString = true
+			case "redact", "sensitive":
+				st.IsRedact = true
+			default:
+				if strings.HasPrefix(opt, groupsTagOptionPrefix) {
+					st.Groups = strings.Split(opt[len(groupsTagOptionPrefix):], "|")
+				}
 			}
 		}
 	}
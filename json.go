@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/goccy/go-json/internal/decoder"
 	"github.com/goccy/go-json/internal/encoder"
 )
 
@@ -278,7 +279,7 @@ func Unmarshal(data []byte, v interface{}) error {
 // in the value pointed to by v. If you implement the UnmarshalerContext interface,
 // call it with ctx as an argument.
 func UnmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
-	return unmarshalContext(ctx, data, v)
+	return unmarshalContext(ctx, data, v, optFuncs...)
 }
 
 func UnmarshalWithOption(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
@@ -310,6 +311,15 @@ type RawMessage = json.RawMessage
 // A Delim is a JSON array or object delimiter, one of [ ] { or }.
 type Delim = json.Delim
 
+// OrderedObject is a JSON object that keeps the order of the members.
+// It's decoded into interface{} instead of map[string]interface{} with DecodeObjectAsOrdered,
+// and is encoded in the original order, so a document can be round-tripped without reordering the keys.
+// Decoding a JSON object into an OrderedObject directly also keeps the order.
+type OrderedObject = decoder.OrderedObject
+
+// OrderedMember is a member of OrderedObject.
+type OrderedMember = decoder.OrderedMember
+
 // Compact appends to dst the JSON-encoded src with
 // insignificant space characters elided.
 func Compact(dst *bytes.Buffer, src []byte) error {
@@ -348,6 +358,13 @@ func HTMLEscape(dst *bytes.Buffer, src []byte) {
 	dst.Write(buf)
 }
 
+// StripComments returns the copy of src whose comments ( // and /* */ ) are replaced with the white spaces.
+// It preprocesses JSONC for the decoders that don't accept the comments, keeping the offsets and the line numbers.
+// Use DecodeRelaxed to decode JSONC directly.
+func StripComments(src []byte) []byte {
+	return decoder.StripComments(src)
+}
+
 // Valid reports whether data is a valid JSON encoding.
 func Valid(data []byte) bool {
 	var v interface{}
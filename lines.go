@@ -0,0 +1,191 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+// A LineError describes the error of decoding a line by LinesDecoder.
+type LineError struct {
+	Line int   // the line number starting from 1
+	Err  error // the error of decoding the line
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("json: line %d: %s", e.Line, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *LineError) Unwrap() error { return e.Err }
+
+// A LinesDecoder reads JSON Lines ( NDJSON ) from an input stream.
+// Each line must contain exactly one JSON value, so a value can't span multiple lines.
+type LinesDecoder struct {
+	s              *decoder.Stream
+	line           int
+	skipBlankLines bool
+	optFuncs       []DecodeOptionFunc
+}
+
+// NewLinesDecoder returns a new decoder that reads JSON Lines from r.
+// The options are applied to all the decoding by the decoder like SetOptions.
+func NewLinesDecoder(r io.Reader, optFuncs ...DecodeOptionFunc) *LinesDecoder {
+	return &LinesDecoder{
+		s:        decoder.NewStream(r),
+		optFuncs: optFuncs,
+	}
+}
+
+// SetOptions sets the options applied to all the subsequent decoding by the decoder.
+// The options passed to DecodeWithOption are applied after them.
+func (d *LinesDecoder) SetOptions(optFuncs ...DecodeOptionFunc) {
+	d.optFuncs = optFuncs
+}
+
+// SkipBlankLines causes the decoder to skip the lines containing only white spaces.
+// By default, such a line is reported as an error.
+func (d *LinesDecoder) SkipBlankLines() {
+	d.skipBlankLines = true
+}
+
+// Line returns the number of the line read last.
+func (d *LinesDecoder) Line() int {
+	return d.line
+}
+
+// Decode reads the next line and stores the JSON value in it to the value pointed to by v.
+// It returns io.EOF at the end of the input, and *LineError if the line can't be decoded.
+func (d *LinesDecoder) Decode(v interface{}) error {
+	return d.DecodeWithOption(v)
+}
+
+// DecodeContext calls Decode with context.Context.
+// Reading the input is aborted when ctx is done in the same way as Decoder.DecodeContext.
+func (d *LinesDecoder) DecodeContext(ctx context.Context, v interface{}, optFuncs ...DecodeOptionFunc) error {
+	d.s.SetContext(ctx)
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+	return d.lineError(d.line, unmarshalContext(ctx, line, v, d.options(optFuncs)...))
+}
+
+// DecodeWithOption calls Decode with DecodeOption.
+func (d *LinesDecoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+	return d.lineError(d.line, unmarshal(line, v, d.options(optFuncs)...))
+}
+
+func (d *LinesDecoder) readLine() ([]byte, error) {
+	for {
+		line, err := d.s.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, d.lineError(d.line+1, err)
+		}
+		d.line++
+		if len(bytes.TrimLeft(line, " \t\r")) > 0 {
+			return line, nil
+		}
+		if !d.skipBlankLines {
+			return nil, d.lineError(d.line, errors.New("blank line"))
+		}
+	}
+}
+
+func (d *LinesDecoder) options(optFuncs []DecodeOptionFunc) []DecodeOptionFunc {
+	if len(optFuncs) == 0 {
+		return d.optFuncs
+	}
+	return append(append([]DecodeOptionFunc{}, d.optFuncs...), optFuncs...)
+}
+
+func (d *LinesDecoder) lineError(line int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &LineError{Line: line, Err: err}
+}
+
+// A LinesEncoder writes JSON Lines ( NDJSON ) to an output stream.
+// Each value is written as a single line even if the indentation is set, and the line is written by one call to Write.
+// It's safe to call the encoding methods concurrently, and the lines written by them are never interleaved.
+type LinesEncoder struct {
+	enc *Encoder
+	mu  sync.Mutex
+}
+
+// NewLinesEncoder returns a new encoder that writes JSON Lines to w.
+// The options are applied to all the encoding by the encoder like SetOptions.
+func NewLinesEncoder(w io.Writer, optFuncs ...EncodeOptionFunc) *LinesEncoder {
+	return &LinesEncoder{enc: NewEncoder(w, optFuncs...)}
+}
+
+// SetOptions sets the options applied to all the subsequent encoding by the encoder.
+// It must not be called concurrently with the encoding methods.
+func (e *LinesEncoder) SetOptions(optFuncs ...EncodeOptionFunc) {
+	e.enc.SetOptions(optFuncs...)
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings like Encoder.SetEscapeHTML.
+// It must not be called concurrently with the encoding methods.
+func (e *LinesEncoder) SetEscapeHTML(on bool) {
+	e.enc.SetEscapeHTML(on)
+}
+
+// Encode writes the JSON encoding of v as a line.
+func (e *LinesEncoder) Encode(v interface{}) error {
+	return e.EncodeWithOption(v)
+}
+
+// EncodeWithOption call Encode with EncodeOption.
+func (e *LinesEncoder) EncodeWithOption(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+
+	err := e.encodeWithOption(ctx, v, optFuncs...)
+
+	encoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+// EncodeContext call Encode with context.Context and EncodeOption.
+func (e *LinesEncoder) EncodeContext(ctx context.Context, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	rctx := encoder.TakeRuntimeContext()
+	rctx.Option.Flag = 0
+	rctx.Option.Flag |= encoder.ContextOption
+	rctx.Option.Context = ctx
+
+	err := e.encodeWithOption(rctx, v, optFuncs...) //nolint: contextcheck
+
+	encoder.ReleaseRuntimeContext(rctx)
+	return err
+}
+
+func (e *LinesEncoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	e.enc.setOption(ctx, optFuncs...)
+	// the line is encoded without the indentation, and is written at once after it's completed.
+	ctx.Option.Flag &= ^encoder.IndentOption
+	buf, err := encode(ctx, v)
+	if err != nil {
+		return err
+	}
+	buf[len(buf)-1] = '\n'
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = (*encoderWriter)(e.enc).Write(buf)
+	return err
+}
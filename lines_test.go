@@ -0,0 +1,128 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goccy/go-json"
+)
+
+func TestLinesDecoder(t *testing.T) {
+	type T struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	t.Run("decode", func(t *testing.T) {
+		src := "{\"id\":1,\"name\":\"a\"}\n  {\"id\": 2, \"name\": \"b\"}  \r\n{\"id\":3,\"name\":\"c\"}"
+		dec := json.NewLinesDecoder(iotest.OneByteReader(strings.NewReader(src)))
+		var got []T
+		for {
+			var v T
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			assertEq(t, "line", len(got)+1, dec.Line())
+			got = append(got, v)
+		}
+		assertEq(t, "values", 3, len(got))
+		assertEq(t, "value", T{ID: 3, Name: "c"}, got[2])
+	})
+	t.Run("blank lines", func(t *testing.T) {
+		src := "1\n\n  \n2\n"
+		dec := json.NewLinesDecoder(strings.NewReader(src))
+		var v int
+		assertErr(t, dec.Decode(&v))
+		err := dec.Decode(&v)
+		var lineErr *json.LineError
+		if !errors.As(err, &lineErr) {
+			t.Fatalf("expected *json.LineError but got %v", err)
+		}
+		assertEq(t, "line", 2, lineErr.Line)
+		assertEq(t, "message", "json: line 2: blank line", err.Error())
+
+		dec = json.NewLinesDecoder(strings.NewReader(src))
+		dec.SkipBlankLines()
+		assertErr(t, dec.Decode(&v))
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "value", 2, v)
+		assertEq(t, "line", 4, dec.Line())
+		if err := dec.Decode(&v); err != io.EOF {
+			t.Fatalf("expected io.EOF but got %v", err)
+		}
+	})
+	t.Run("one value per line", func(t *testing.T) {
+		for _, src := range []string{"1 2\n", "{\"id\":\n1}\n", "[1,\n2]\n", "1\n{\"id\":}\n"} {
+			dec := json.NewLinesDecoder(strings.NewReader(src))
+			var err error
+			for err == nil {
+				var v interface{}
+				err = dec.Decode(&v)
+			}
+			var lineErr *json.LineError
+			if !errors.As(err, &lineErr) {
+				t.Fatalf("expected *json.LineError for %q but got %v", src, err)
+			}
+			if !strings.HasPrefix(err.Error(), "json: line ") {
+				t.Fatalf("unexpected error message %q", err.Error())
+			}
+		}
+	})
+	t.Run("options", func(t *testing.T) {
+		dec := json.NewLinesDecoder(strings.NewReader("{\"id\":1,\"x\":1}\n{\"id\":1,\"x\":1}\n"))
+		var v T
+		assertErr(t, dec.Decode(&v))
+		dec.SetOptions(json.DecodeDisallowUnknownFields())
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestLinesEncoder(t *testing.T) {
+	t.Run("single line", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewLinesEncoder(&buf, json.IndentWith("", "  "))
+		assertErr(t, enc.Encode(map[string]interface{}{"a": []int{1, 2}, "b": "c\nd"}))
+		assertErr(t, enc.EncodeWithOption(nil, json.IndentWith("  ", "  ")))
+		assertEq(t, "lines", "{\"a\":[1,2],\"b\":\"c\\nd\"}\nnull\n", buf.String())
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		w := &recordWriter{}
+		enc := json.NewLinesEncoder(w)
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if err := enc.Encode(map[string]int{"id": i}); err != nil {
+					t.Error(err)
+				}
+			}(i)
+		}
+		wg.Wait()
+		assertEq(t, "writes", 50, len(w.writes))
+		dec := json.NewLinesDecoder(strings.NewReader(w.String()))
+		var ids []int
+		for {
+			var v map[string]int
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			ids = append(ids, v["id"])
+		}
+		sort.Ints(ids)
+		for i, id := range ids {
+			assertEq(t, "id", i, id)
+		}
+	})
+}
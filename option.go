@@ -17,6 +17,28 @@ func UnorderedMap() EncodeOptionFunc {
 	}
 }
 
+// IndentWith indents the output in the same way as MarshalIndent.
+// IndentWith("", "") disables the indentation like Encoder.SetIndent.
+func IndentWith(prefix, indent string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		if prefix == "" && indent == "" {
+			opt.Flag &= ^encoder.IndentOption
+			return
+		}
+		opt.Flag |= encoder.IndentOption
+		opt.Prefix = prefix
+		opt.Indent = indent
+	}
+}
+
+// EscapeHTML enables escaping of HTML characters ( '&', '<', '>' ) when encoding string.
+// It's enabled by default, so use it to override DisableHTMLEscape set by Encoder.SetOptions.
+func EscapeHTML() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.HTMLEscapeOption
+	}
+}
+
 // DisableHTMLEscape disables escaping of HTML characters ( '&', '<', '>' ) when encoding string.
 func DisableHTMLEscape() EncodeOptionFunc {
 	return func(opt *EncodeOption) {
@@ -63,6 +85,39 @@ func Colorize(scheme *ColorScheme) EncodeOptionFunc {
 	}
 }
 
+// Redact writes "***" instead of the values of the fields tagged with `redact` or `sensitive`.
+// Without this option, those fields are encoded as usual.
+func Redact() EncodeOptionFunc {
+	return RedactWithMask(encoder.DefaultRedactMask)
+}
+
+// RedactWithMask writes mask instead of the values of the fields tagged with `redact` or `sensitive`.
+func RedactWithMask(mask string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.RedactOption
+		opt.RedactMask = mask
+	}
+}
+
+// Groups activates the groups of the fields tagged with `groups=name1|name2`.
+// Such a field is encoded only if one of its groups is active,
+// and the groups set to the context by SetGroupsToContext are active too when encoding with MarshalContext.
+func Groups(groups ...string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Groups = groups
+	}
+}
+
+// FlushThreshold makes Encoder write the encoded bytes to its writer whenever they exceed n bytes,
+// instead of writing the whole value at the end. The buffer stays around n bytes even for huge slices and maps,
+// but the sorted map is written after all of its entries are encoded, so use UnorderedMap together for the huge maps.
+// The error returned by the writer stops the encoding. This option has no effect on Marshal.
+func FlushThreshold(n int) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.FlushThreshold = n
+	}
+}
+
 type DecodeOption = decoder.Option
 type DecodeOptionFunc func(*DecodeOption)
 
@@ -77,3 +132,73 @@ func DecodeFieldPriorityFirstWin() DecodeOptionFunc {
 		opt.Flags |= decoder.FirstWinOption
 	}
 }
+
+// DecodeUseNumber decodes a number into an interface{} as a Number instead of as a float64.
+// It's the same as Decoder.UseNumber.
+func DecodeUseNumber() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.UseNumberOption
+	}
+}
+
+// DecodeIntegersAsInt64 decodes an integer into an interface{} as an int64 instead of as a float64,
+// including the ones in the nested maps and slices.
+// The integer that overflows int64 is decoded as a uint64, and the one that overflows uint64 as a Number.
+// The other numbers are decoded as float64, or as Number with DecodeUseNumber.
+func DecodeIntegersAsInt64() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.IntegersAsInt64Option
+	}
+}
+
+// DecodeDisallowUnknownFields returns an error when the destination is a struct
+// and the input contains object keys which do not match any non-ignored, exported fields in the destination.
+// It's the same as Decoder.DisallowUnknownFields.
+func DecodeDisallowUnknownFields() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.DisallowUnknownFieldsOption
+	}
+}
+
+// DecodeRelaxed accepts the relaxed syntax of JSONC and JSON5 used by the configuration files.
+// They are the comments ( // and /* */ ), the trailing commas in objects and arrays,
+// the unquoted object keys and the single-quoted strings.
+// The decoders accept the syntax as they read the input, so the offsets in errors are of the input.
+// It applies to the call only when passed to Decoder.DecodeWithOption, and to every call when passed to NewDecoder or Decoder.SetOptions.
+func DecodeRelaxed() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.RelaxedOption
+	}
+}
+
+// DecodeObjectAsOrdered decodes a JSON object into an interface{} as an OrderedObject instead of as a map[string]interface{}.
+// The order of the members is kept, so the decoded value is encoded in the same order.
+func DecodeObjectAsOrdered() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.OrderedObjectOption
+	}
+}
+
+type PathSetOption = decoder.PathSetOption
+type PathSetOptionFunc func(*PathSetOption)
+
+// PathGrowSlice grows slices when Path.SetValue refers to an index beyond their length.
+// The added elements other than the target have zero values.
+func PathGrowSlice() PathSetOptionFunc {
+	return func(opt *PathSetOption) {
+		opt.GrowSlice = true
+	}
+}
+
+type PathOption = decoder.PathBuildOption
+type PathOptionFunc func(*PathOption)
+
+// PathRFC9535 builds JSON Path strictly following RFC 9535.
+// It supports the whole grammar including the slice and filter selectors,
+// and the length, count, match, search and value function extensions.
+// The paths that don't conform to it are rejected, and the results are reported with the normalized paths defined by it.
+func PathRFC9535() PathOptionFunc {
+	return func(opt *PathOption) {
+		opt.RFC9535 = true
+	}
+}
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/goccy/go-json/internal/decoder"
@@ -20,8 +21,14 @@ import (
 // Escape Rule
 // single quote style escape: e.g.) `$['a.b'].c`
 // double quote style escape: e.g.) `$."a.b".c`
-func CreatePath(p string) (*Path, error) {
-	path, err := decoder.PathString(p).Build()
+//
+// With the PathRFC9535 option, the path is parsed strictly following RFC 9535 instead of the rule above.
+func CreatePath(p string, optFuncs ...PathOptionFunc) (*Path, error) {
+	opt := &PathOption{}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+	path, err := decoder.PathString(p).BuildWithOption(opt)
 	if err != nil {
 		return nil, err
 	}
@@ -53,17 +60,57 @@ func (p *Path) Extract(data []byte, optFuncs ...DecodeOptionFunc) ([][]byte, err
 	return extractFromPath(p, data, optFuncs...)
 }
 
+// Set replaces the values corresponding to JSON Path in data with the encoded value and returns the edited JSON.
+// If the last field name or index of the path doesn't exist, it's inserted and missing parents are created.
+// The rest of data is kept untouched, including its whitespace and key order.
+// The recursive descent selector matches the values at any depth, including the ones nested in another match.
+func (p *Path) Set(data []byte, value interface{}) ([]byte, error) {
+	encoded, err := Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return p.path.Set(data, encoded)
+}
+
+// Delete removes the values corresponding to JSON Path from data and returns the edited JSON.
+// The rest of data is kept untouched, including its whitespace and key order.
+// The recursive descent selector matches the values at any depth, including the ones nested in another match.
+func (p *Path) Delete(data []byte) ([]byte, error) {
+	return p.path.Delete(data)
+}
+
+// PathMatch is a value extracted by JSON Path together with its location in the source.
+type PathMatch = decoder.PathMatch
+
+// ExtractWithLocation extracts specific JSON strings like Extract,
+// and reports the normalized path and the byte offsets in data of each of them.
+// This tells which concrete element a result comes from for the index all and recursive descent selectors.
+func (p *Path) ExtractWithLocation(data []byte) ([]PathMatch, error) {
+	return p.path.Matches(data)
+}
+
 // PathString returns original JSON Path string.
 func (p *Path) PathString() string {
 	return p.path.String()
 }
 
 // Unmarshal extract and decode the value of the part corresponding to JSON Path from the input data.
+// Value is decoded from the matched JSON as it is: it's the match for the path that matches at most one value,
+// and the array of the matches for the others.
 func (p *Path) Unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
 	contents, err := extractFromPath(p, data, optFuncs...)
 	if err != nil {
 		return err
 	}
+	switch dst := v.(type) {
+	case *Value:
+		return p.unmarshalValue(contents, dst, optFuncs...)
+	case **Value:
+		if *dst == nil {
+			*dst = &Value{}
+		}
+		return p.unmarshalValue(contents, *dst, optFuncs...)
+	}
 	results := make([]interface{}, 0, len(contents))
 	for _, content := range contents {
 		var result interface{}
@@ -78,7 +125,97 @@ func (p *Path) Unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFun
 	return nil
 }
 
+func (p *Path) unmarshalValue(contents [][]byte, v *Value, optFuncs ...DecodeOptionFunc) error {
+	if p.path.Singular() {
+		if len(contents) == 0 {
+			return fmt.Errorf("failed to cast to Value from empty slice")
+		}
+		return UnmarshalWithOption(contents[0], v, optFuncs...)
+	}
+	arr := NewArray()
+	for _, content := range contents {
+		elem := &Value{}
+		if err := UnmarshalWithOption(content, elem, optFuncs...); err != nil {
+			return err
+		}
+		if err := arr.Append(elem); err != nil {
+			return err
+		}
+	}
+	*v = *arr
+	return nil
+}
+
+// Values evaluates JSON Path on v and returns the matched values.
+// They are the references to the values in v, so editing them edits v.
+func (p *Path) Values(v *Value) ([]*Value, error) {
+	return p.path.Values(v)
+}
+
 // Get extract and substitute the value of the part corresponding to JSON Path from the input value.
 func (p *Path) Get(src, dst interface{}) error {
+	if _, ok := src.(*Value); ok || p.path.RFC9535() {
+		// RFC 9535 path and Value are evaluated on JSON, so the value is encoded beforehand.
+		data, err := Marshal(src)
+		if err != nil {
+			return err
+		}
+		return p.Unmarshal(data, dst)
+	}
 	return p.path.Get(reflect.ValueOf(src), reflect.ValueOf(dst))
 }
+
+// SetValue substitutes value for the part corresponding to JSON Path in dst, which must be a non-nil pointer.
+// It is the counterpart of Get: nil pointers, maps and slices are allocated on the way,
+// and every match is updated for the index all and recursive descent selectors.
+// dst is left untouched if it fails, and the number that would be truncated by the integer type is reported as *UnmarshalTypeError.
+func (p *Path) SetValue(dst, value interface{}, optFuncs ...PathSetOptionFunc) error {
+	opt := &PathSetOption{}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+	return p.path.SetValue(reflect.ValueOf(dst), reflect.ValueOf(value), opt)
+}
+
+// PathSet is a compiled set of JSON Paths, which are extracted together in a single pass over the input.
+// Each path matches the same values as Path.Extract.
+type PathSet struct {
+	set   *decoder.PathSet
+	paths []string
+}
+
+// CreatePathSet creates a PathSet from JSON Path strings.
+// See CreatePath for the JSON Path rule.
+func CreatePathSet(paths ...string) (*PathSet, error) {
+	built := make([]*decoder.Path, 0, len(paths))
+	for _, p := range paths {
+		path, err := decoder.PathString(p).Build()
+		if err != nil {
+			return nil, err
+		}
+		built = append(built, path)
+	}
+	return &PathSet{
+		set:   decoder.NewPathSet(built),
+		paths: paths,
+	}, nil
+}
+
+// Extract extracts specific JSON strings for every path of the set in a single pass over data.
+// The results are keyed by the path strings passed to CreatePathSet.
+//
+// When no path uses the index all ([*]) or recursive descent (..) selectors,
+// the scan stops as soon as every path has matched and the rest of data is not read.
+// Then only the first one is extracted for the duplicated keys.
+// It fails if any path fails on data in the same way as Path.Extract.
+func (s *PathSet) Extract(data []byte) (map[string][][]byte, error) {
+	contents, err := s.set.Extract(data)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string][][]byte, len(s.paths))
+	for i, path := range s.paths {
+		ret[path] = contents[i]
+	}
+	return ret, nil
+}
@@ -2,9 +2,16 @@ package json_test
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goccy/go-json"
 )
@@ -232,3 +239,828 @@ func TestGetPath(t *testing.T) {
 		}
 	})
 }
+
+func TestSetPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		src      string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "replace field",
+			path:     "$.a.b",
+			src:      "{\n  \"a\": {\"b\": 10, \"c\": true},\n  \"b\": \"text\"\n}",
+			value:    20,
+			expected: "{\n  \"a\": {\"b\": 20, \"c\": true},\n  \"b\": \"text\"\n}",
+		},
+		{
+			name:     "replace index",
+			path:     "$.a[1]",
+			src:      `{"a": [1, 2, 3]}`,
+			value:    map[string]int{"x": 1},
+			expected: `{"a": [1, {"x":1}, 3]}`,
+		},
+		{
+			name:     "replace all",
+			path:     "$.a[*].b",
+			src:      `{"a":[{"b":1},{"b":2,"c":3}]}`,
+			value:    "x",
+			expected: `{"a":[{"b":"x"},{"b":"x","c":3}]}`,
+		},
+		{
+			name:     "recursive",
+			path:     "$..b",
+			src:      `[{"b":1},[{"b":2}]]`,
+			value:    0,
+			expected: `[{"b":0},[{"b":0}]]`,
+		},
+		{
+			name:     "recursive nested",
+			path:     "$..b",
+			src:      `{"b": {"b": 1}, "a": {"b": 2}}`,
+			value:    0,
+			expected: `{"b": 0, "a": {"b": 0}}`,
+		},
+		{
+			name:     "insert field",
+			path:     "$.a.d",
+			src:      "{\n  \"a\": {\n    \"b\": 1\n  }\n}",
+			value:    true,
+			expected: "{\n  \"a\": {\n    \"b\": 1,\n    \"d\": true\n  }\n}",
+		},
+		{
+			name:     "insert field into empty object",
+			path:     `$['a.b']`,
+			src:      `{}`,
+			value:    1,
+			expected: `{"a.b":1}`,
+		},
+		{
+			name:     "create parents",
+			path:     "$.a.b[1]",
+			src:      `{"x": 1}`,
+			value:    "v",
+			expected: `{"x": 1,"a": {"b":[null,"v"]}}`,
+		},
+		{
+			name:     "append index",
+			path:     "$[3]",
+			src:      `[1, 2]`,
+			value:    3,
+			expected: `[1, 2, null, 3]`,
+		},
+		{
+			name:     "escaped key and value",
+			path:     "$.z",
+			src:      `{"k\"q":"a\nb","z":2}`,
+			value:    3,
+			expected: `{"k\"q":"a\nb","z":3}`,
+		},
+		{
+			name:     "insert after escaped key",
+			path:     "$.n",
+			src:      `{"k\"q":"a\\b"}`,
+			value:    "\"",
+			expected: `{"k\"q":"a\\b","n":"\""}`,
+		},
+		{
+			name:     "recursive over escaped keys",
+			path:     "$..b",
+			src:      `{"a\tb":{"b":"x\"y"},"b":1}`,
+			value:    0,
+			expected: `{"a\tb":{"b":0},"b":0}`,
+		},
+		{
+			name:     "root",
+			path:     "$",
+			src:      ` {"a":1} `,
+			value:    []int{1},
+			expected: ` [1] `,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := json.CreatePath(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := path.Set([]byte(test.src), test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.expected {
+				t.Fatalf("failed to set path: expected %q but got %q", test.expected, got)
+			}
+		})
+	}
+	t.Run("non-object parent", func(t *testing.T) {
+		path, err := json.CreatePath("$.a.b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := path.Set([]byte(`{"a":[1]}`), 1); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestDeletePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "first field",
+			path:     "$.a",
+			src:      "{\n  \"a\": 1,\n  \"b\": 2\n}",
+			expected: "{\n  \"b\": 2\n}",
+		},
+		{
+			name:     "last field",
+			path:     "$.b",
+			src:      "{\n  \"a\": 1,\n  \"b\": 2\n}",
+			expected: "{\n  \"a\": 1\n}",
+		},
+		{
+			name:     "only field",
+			path:     "$.a.b",
+			src:      `{"a": { "b": [1, 2] }, "c": 3}`,
+			expected: `{"a": {}, "c": 3}`,
+		},
+		{
+			name:     "escaped key",
+			path:     `$['a\b']`,
+			src:      `{"x":1, "a\\b" : 2}`,
+			expected: `{"x":1}`,
+		},
+		{
+			name:     "escaped key and value kept",
+			path:     "$.z",
+			src:      `{"k\"q":"a\u0022\nb","z":2}`,
+			expected: `{"k\"q":"a\u0022\nb"}`,
+		},
+		{
+			name:     "recursive over escaped keys",
+			path:     "$..b",
+			src:      `{"a\"b":{"b":"x\"y","c":"\\"},"b":1}`,
+			expected: `{"a\"b":{"c":"\\"}}`,
+		},
+		{
+			name:     "index",
+			path:     "$[1]",
+			src:      `[1, 2, 3]`,
+			expected: `[1, 3]`,
+		},
+		{
+			name:     "all",
+			path:     "$.a[*]",
+			src:      `{"a": [1, 2, 3]}`,
+			expected: `{"a": []}`,
+		},
+		{
+			name:     "recursive",
+			path:     "$..b",
+			src:      `[{"b":1,"c":2},[{"b":2}]]`,
+			expected: `[{"c":2},[{}]]`,
+		},
+		{
+			name:     "recursive nested",
+			path:     "$..b",
+			src:      `{"b": {"b": 1}, "a": [{"x": {"b": 2}}], "c": 3}`,
+			expected: `{"a": [{"x": {}}], "c": 3}`,
+		},
+		{
+			name:     "recursive with child",
+			path:     "$.a..b[0]",
+			src:      `{"a": {"b": [1, 2], "c": {"b": [3]}}, "b": [4]}`,
+			expected: `{"a": {"b": [2], "c": {"b": []}}, "b": [4]}`,
+		},
+		{
+			name:     "missing",
+			path:     "$.x",
+			src:      `{"a": 1}`,
+			expected: `{"a": 1}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := json.CreatePath(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := path.Delete([]byte(test.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.expected {
+				t.Fatalf("failed to delete path: expected %q but got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetValuePath(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type Config struct {
+		Name  string              `json:"name"`
+		Inner *struct{ Port int } `json:"inner"`
+		Tags  map[string]string   `json:"tags"`
+		Items []Item              `json:"items"`
+	}
+	t.Run("allocate pointer", func(t *testing.T) {
+		var cfg Config
+		path, err := json.CreatePath("$.inner.Port")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, 8080); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Inner == nil || cfg.Inner.Port != 8080 {
+			t.Fatal("failed to set value")
+		}
+	})
+	t.Run("allocate map", func(t *testing.T) {
+		var cfg Config
+		path, err := json.CreatePath("$.tags.env")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, "prod"); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Tags["env"] != "prod" {
+			t.Fatal("failed to set value")
+		}
+	})
+	t.Run("grow slice", func(t *testing.T) {
+		var cfg Config
+		path, err := json.CreatePath("$.items[1].name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, "b"); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := path.SetValue(&cfg, "b", json.PathGrowSlice()); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cfg.Items, []Item{{}, {Name: "b"}}) {
+			t.Fatalf("failed to set value: %v", cfg.Items)
+		}
+	})
+	t.Run("index all", func(t *testing.T) {
+		cfg := Config{Items: []Item{{ID: 1}, {ID: 2}}}
+		path, err := json.CreatePath("$.items[*].name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, "x"); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cfg.Items, []Item{{ID: 1, Name: "x"}, {ID: 2, Name: "x"}}) {
+			t.Fatalf("failed to set value: %v", cfg.Items)
+		}
+	})
+	t.Run("recursive", func(t *testing.T) {
+		var v interface{}
+		if err := json.Unmarshal([]byte(`{"a":[{"b":1,"c":true},{"b":2}],"a2":{"b":4}}`), &v); err != nil {
+			t.Fatal(err)
+		}
+		path, err := json.CreatePath("$..b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&v, 0); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != `{"a":[{"b":0,"c":true},{"b":0}],"a2":{"b":0}}` {
+			t.Fatalf("failed to set value: %s", got)
+		}
+	})
+	t.Run("interface", func(t *testing.T) {
+		var v interface{}
+		path, err := json.CreatePath("$.a.b[0]")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&v, 1.5, json.PathGrowSlice()); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1.5}}}) {
+			t.Fatalf("failed to set value: %v", v)
+		}
+	})
+	t.Run("convert", func(t *testing.T) {
+		var cfg Config
+		path, err := json.CreatePath("$.items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, []Item{{ID: 3}}); err != nil {
+			t.Fatal(err)
+		}
+		if len(cfg.Items) != 1 || cfg.Items[0].ID != 3 {
+			t.Fatal("failed to set value")
+		}
+	})
+	t.Run("nil dst", func(t *testing.T) {
+		path, err := json.CreatePath("$.name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(nil, "x"); err == nil {
+			t.Fatal("expected error")
+		}
+		var cfg *Config
+		if err := path.SetValue(cfg, "x"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("untouched on error", func(t *testing.T) {
+		var v interface{}
+		path, err := json.CreatePath("$.a.b[1]")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&v, 1); err == nil {
+			t.Fatal("expected error")
+		}
+		if v != nil {
+			t.Fatalf("dst is mutated: %v", v)
+		}
+
+		cfg := Config{Items: []Item{{ID: 1}, {ID: 2}}}
+		path, err = json.CreatePath("$.items[*].id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := path.SetValue(&cfg, "x"); err == nil {
+			t.Fatal("expected error")
+		}
+		if !reflect.DeepEqual(cfg.Items, []Item{{ID: 1}, {ID: 2}}) {
+			t.Fatalf("dst is mutated: %v", cfg.Items)
+		}
+	})
+	t.Run("truncate", func(t *testing.T) {
+		var cfg Config
+		path, err := json.CreatePath("$.inner.Port")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var typeErr *json.UnmarshalTypeError
+		if err := path.SetValue(&cfg, 1.5); !errors.As(err, &typeErr) {
+			t.Fatalf("expected UnmarshalTypeError but got %v", err)
+		}
+		if cfg.Inner != nil {
+			t.Fatal("dst is mutated")
+		}
+		if err := path.SetValue(&cfg, uint64(math.MaxUint64)); !errors.As(err, &typeErr) {
+			t.Fatalf("expected UnmarshalTypeError but got %v", err)
+		}
+		if err := path.SetValue(&cfg, 8080.0); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Inner.Port != 8080 {
+			t.Fatal("failed to set value")
+		}
+	})
+}
+
+func TestDecoderPath(t *testing.T) {
+	src := `{"a":{"b":10,"c":true},"b":"text","items":[{"id":1,"tags":["x"]},{"id":2},{"id":3,"tags":["y","z"]}]}
+[{"b":1},[{"b":2}]]`
+	t.Run("ExtractPath", func(t *testing.T) {
+		tests := []struct {
+			path     string
+			expected []string
+		}{
+			{path: "$.a.b", expected: []string{`10`}},
+			{path: "$.a", expected: []string{`{"b":10,"c":true}`}},
+			{path: "$.items[*].id", expected: []string{`1`, `2`, `3`}},
+			{path: "$.items[2].tags[1]", expected: []string{`"z"`}},
+			{path: "$.missing", expected: []string{}},
+		}
+		for _, test := range tests {
+			t.Run(test.path, func(t *testing.T) {
+				path, err := json.CreatePath(test.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src)))
+				contents, err := dec.ExtractPath(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := []string{}
+				for _, content := range contents {
+					got = append(got, string(content))
+				}
+				if !reflect.DeepEqual(got, test.expected) {
+					t.Fatalf("failed to extract: expected %q but got %q", test.expected, got)
+				}
+				// the next value can be read after the path extraction.
+				var v interface{}
+				if err := dec.Decode(&v); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	})
+	t.Run("DecodePath", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(src))
+		path, err := json.CreatePath("$.items[*].id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int
+		if err := dec.DecodePath(path, &ids); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+			t.Fatalf("failed to decode path: %v", ids)
+		}
+		recursive, err := json.CreatePath("$..b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b []int
+		if err := dec.DecodePath(recursive, &b); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(b, []int{1, 2}) {
+			t.Fatalf("failed to decode path: %v", b)
+		}
+	})
+	t.Run("large input", func(t *testing.T) {
+		const n = 100000
+		var buf bytes.Buffer
+		buf.WriteString(`{"items":[`)
+		for i := 0; i < n; i++ {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(&buf, `{"id":%d,"name":"name-%d","payload":[1,2,3,{"x":"%s"}]}`, i, i, strings.Repeat("p", 64))
+		}
+		buf.WriteString(`],"count":100000}`)
+		path, err := json.CreatePath("$.count")
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := json.NewDecoder(&buf).ExtractPath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contents) != 1 || string(contents[0]) != "100000" {
+			t.Fatalf("failed to extract: %q", contents)
+		}
+	})
+}
+
+func TestExtractPathWithLocation(t *testing.T) {
+	src := []byte(`{"items": [{"id": 1}, {"name": "x"}, {"id": 3, "a\\b": {"id": 4}}], "id": 0}`)
+	tests := []struct {
+		path     string
+		expected []json.PathMatch
+	}{
+		{
+			path: "$",
+			expected: []json.PathMatch{
+				{NormalizedPath: "$", Start: 0, End: int64(len(src))},
+			},
+		},
+		{
+			path: "$.items[*].id",
+			expected: []json.PathMatch{
+				{NormalizedPath: "$['items'][0]['id']", Start: 18, End: 19},
+				{NormalizedPath: "$['items'][2]['id']", Start: 44, End: 45},
+			},
+		},
+		{
+			path: `$.items[2]['a\b']`,
+			expected: []json.PathMatch{
+				{NormalizedPath: `$['items'][2]['a\\b']`, Start: 55, End: 64},
+			},
+		},
+		{
+			path: "$.items..id",
+			expected: []json.PathMatch{
+				{NormalizedPath: "$['items'][0]['id']", Start: 18, End: 19},
+				{NormalizedPath: "$['items'][2]['id']", Start: 44, End: 45},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := json.CreatePath(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			matches, err := path.ExtractWithLocation(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != len(test.expected) {
+				t.Fatalf("failed to extract: expected %d matches but got %d", len(test.expected), len(matches))
+			}
+			for i, match := range matches {
+				expected := test.expected[i]
+				if match.NormalizedPath != expected.NormalizedPath || match.Start != expected.Start || match.End != expected.End {
+					t.Fatalf("failed to extract: expected %+v but got %+v", expected, match)
+				}
+				if !bytes.Equal(match.Value, src[match.Start:match.End]) {
+					t.Fatalf("failed to extract: unexpected value %q", match.Value)
+				}
+			}
+		})
+	}
+	t.Run("escaped", func(t *testing.T) {
+		for _, test := range []struct {
+			path string
+			opts []json.PathOptionFunc
+			src  string
+			n    int
+		}{
+			{path: "$..a", src: `{"k\"q": 1, "a": "x\"y"}`, n: 1},
+			{path: "$.b[*]", src: `{"k\"q": 1, "b": ["\u00e9\n", "p\\q"]}`, n: 2},
+			{path: "$..a", opts: []json.PathOptionFunc{json.PathRFC9535()}, src: `{"k\"q": {"a": "x\"y"}, "a": ["\n", {"a": "p\\q"}]}`, n: 3},
+			{path: "$..*", opts: []json.PathOptionFunc{json.PathRFC9535()}, src: `{"k\"q": {"a": "x\"y"}, "a": ["\n", {"a": "p\\q"}]}`, n: 6},
+		} {
+			path, err := json.CreatePath(test.path, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src := []byte(test.src)
+			matches, err := path.ExtractWithLocation(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != test.n {
+				t.Fatalf("failed to extract %s: expected %d matches but got %d", test.path, test.n, len(matches))
+			}
+			for _, match := range matches {
+				if !bytes.Equal(match.Value, src[match.Start:match.End]) {
+					t.Fatalf("failed to extract %s: expected %q but got %q", test.path, src[match.Start:match.End], match.Value)
+				}
+			}
+		}
+	})
+}
+
+func TestPathSet(t *testing.T) {
+	src := []byte(`{"a":{"b":10,"c":true},"b":"text","items":[{"id":1,"b":[{"b":5}]},{"id":2}],"d":null}`)
+	paths := []string{"$.a.b", "$.a", "$.b", "$.items[*].id", "$.items[1]", "$.missing", "$"}
+	set, err := json.CreatePathSet(paths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := set.Extract(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"$.a.b":         {`10`},
+		"$.a":           {`{"b":10,"c":true}`},
+		"$.b":           {`"text"`},
+		"$.items[*].id": {`1`, `2`},
+		"$.items[1]":    {`{"id":2}`},
+		"$.missing":     nil,
+		"$":             {string(src)},
+	}
+	for _, path := range paths {
+		var contents []string
+		for _, content := range got[path] {
+			contents = append(contents, string(content))
+		}
+		if !reflect.DeepEqual(contents, expected[path]) {
+			t.Fatalf("failed to extract %s: expected %q but got %q", path, expected[path], contents)
+		}
+	}
+	t.Run("same as Extract", func(t *testing.T) {
+		srcs := []string{
+			`{"a":{"b":10,"c":true},"b":"te\u0078t","items":[{"id":1,"b":[{"b":5}]},{"id":2}],"d":null}`,
+			`[{"b":1}, [{"b":2}], {"b":{"c":3}}]`,
+			`{"b": {"b": 1}, "x": {"b": {"c": 1}}, "c": [1, 2]}`,
+			`{"items":[{"tags":["a"]},{"tags":["b","c"]}],"tags":[1]}`,
+			`"text"`,
+		}
+		paths := []string{
+			"$", "$.a", "$.b", "$.a.b", "$.b.c", "$.d.x", "$.c[1]", "$[0]", "$[*].b", "$[2].b.c",
+			"$.items[*].id", "$.items[*].tags[0]", "$.items[5]",
+			"$..b", "$..b.c", "$..c", "$..tags[0]", "$.x..c", "$..id",
+		}
+		for _, src := range srcs {
+			var valid []string
+			for _, p := range paths {
+				path, err := json.CreatePath(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// the path failing on src fails the whole set.
+				if _, err := path.Extract([]byte(src)); err == nil {
+					valid = append(valid, p)
+				}
+			}
+			set, err := json.CreatePathSet(valid...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := set.Extract([]byte(src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range valid {
+				path, err := json.CreatePath(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected, err := path.Extract([]byte(src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if fmt.Sprintf("%q", got[p]) != fmt.Sprintf("%q", expected) {
+					t.Fatalf("failed to extract %s from %s: expected %q but got %q", p, src, expected, got[p])
+				}
+			}
+		}
+		set, err := json.CreatePathSet("$.b", "$.a[0]")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := set.Extract([]byte(`{"a":{"x":1},"b":2}`)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("stop early", func(t *testing.T) {
+		set, err := json.CreatePathSet("$.a", "$.b[0]")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the input after the matches is broken, but it's never read.
+		got, err := set.Extract([]byte(`{"b":[1,2],"a":{"x":1}, "c": [}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got["$.a"][0]) != `{"x":1}` || string(got["$.b[0]"][0]) != `1` {
+			t.Fatalf("failed to extract: %q", got)
+		}
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		if _, err := json.CreatePathSet("$.a", "a"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// TestPathRFC9535Examples runs the tests written from the examples and the grammar of RFC 9535.
+// The file uses the format of the JSONPath Compliance Test Suite, but it is not the suite itself.
+func TestPathRFC9535Examples(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "jsonpath", "rfc9535.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suite struct {
+		Tests []struct {
+			Name            string          `json:"name"`
+			Selector        string          `json:"selector"`
+			Document        json.RawMessage `json:"document"`
+			Result          []interface{}   `json:"result"`
+			ResultPaths     []string        `json:"result_paths"`
+			Results         [][]interface{} `json:"results"`
+			ResultsPaths    [][]string      `json:"results_paths"`
+			InvalidSelector bool            `json:"invalid_selector"`
+		} `json:"tests"`
+	}
+	if err := json.Unmarshal(data, &suite); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range suite.Tests {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			path, err := json.CreatePath(test.Selector, json.PathRFC9535())
+			if test.InvalidSelector {
+				if err == nil {
+					t.Fatalf("expected error for %q", test.Selector)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			matches, err := path.ExtractWithLocation(test.Document)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := []interface{}{}
+			paths := []string{}
+			for _, match := range matches {
+				var v interface{}
+				if err := json.Unmarshal(match.Value, &v); err != nil {
+					t.Fatal(err)
+				}
+				values = append(values, v)
+				paths = append(paths, match.NormalizedPath)
+			}
+			results := test.Results
+			if test.Result != nil {
+				results = [][]interface{}{test.Result}
+			}
+			resultsPaths := test.ResultsPaths
+			if test.ResultPaths != nil {
+				resultsPaths = [][]string{test.ResultPaths}
+			}
+			for i, result := range results {
+				if !reflect.DeepEqual(values, result) {
+					continue
+				}
+				if len(resultsPaths) != 0 && !reflect.DeepEqual(paths, resultsPaths[i]) {
+					t.Fatalf("%q: unexpected normalized paths %q", test.Selector, paths)
+				}
+				return
+			}
+			t.Fatalf("%q: unexpected result %v", test.Selector, values)
+		})
+	}
+}
+
+func TestPathRFC9535(t *testing.T) {
+	src := []byte(`{"items": [{"id": 1, "price": 5}, {"id": 2, "price": 15}, {"id": 3, "price": 25}]}`)
+	path, err := json.CreatePath("$.items[?@.price > 10].id", json.PathRFC9535())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("PathString", func(t *testing.T) {
+		if path.PathString() != "$.items[?@.price > 10].id" {
+			t.Fatalf("unexpected path string %q", path.PathString())
+		}
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		var ids []int
+		if err := path.Unmarshal(src, &ids); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, []int{2, 3}) {
+			t.Fatalf("unexpected ids %v", ids)
+		}
+	})
+	t.Run("Get", func(t *testing.T) {
+		type Item struct {
+			ID    int `json:"id"`
+			Price int `json:"price"`
+		}
+		src := struct {
+			Items []Item `json:"items"`
+		}{Items: []Item{{ID: 1, Price: 20}, {ID: 2, Price: 5}}}
+		var ids []int
+		if err := path.Get(src, &ids); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, []int{1}) {
+			t.Fatalf("unexpected ids %v", ids)
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		got, err := path.Set(src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"items": [{"id": 1, "price": 5}, {"id": 0, "price": 15}, {"id": 0, "price": 25}]}`
+		if string(got) != expected {
+			t.Fatalf("expected %s but got %s", expected, got)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		path, err := json.CreatePath("$.items[?@.price > 10]", json.PathRFC9535())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := path.Delete(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"items": [{"id": 1, "price": 5}]}`
+		if string(got) != expected {
+			t.Fatalf("expected %s but got %s", expected, got)
+		}
+	})
+	t.Run("error position", func(t *testing.T) {
+		_, err := json.CreatePath("$.items[?@.* == 1]", json.PathRFC9535())
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if !strings.Contains(err.Error(), "at 9") {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
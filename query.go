@@ -8,6 +8,8 @@ type (
 	// FieldQuery you can dynamically filter the fields in the structure by creating a FieldQuery,
 	// adding it to context.Context using SetFieldQueryToContext and then passing it to MarshalContext.
 	// This is a type-safe operation, so it is faster than filtering using map[string]interface{}.
+	// The same query passed to UnmarshalContext or Decoder.DecodeContext limits the fields to decode,
+	// and the values of the other fields are skipped without being allocated.
 	FieldQuery       = encoder.FieldQuery
 	FieldQueryString = encoder.FieldQueryString
 )
@@ -17,6 +19,11 @@ var (
 	FieldQueryFromContext = encoder.FieldQueryFromContext
 	// SetFieldQueryToContext set current FieldQuery to context.Context.
 	SetFieldQueryToContext = encoder.SetFieldQueryToContext
+	// GroupsFromContext get the active groups from context.Context.
+	GroupsFromContext = encoder.GroupsFromContext
+	// SetGroupsToContext set the active groups to context.Context.
+	// The fields tagged with `groups=name1|name2` are encoded only if one of their groups is active.
+	SetGroupsToContext = encoder.SetGroupsToContext
 )
 
 // BuildFieldQuery builds FieldQuery by fieldName or sub field query.
@@ -45,3 +52,25 @@ func (q *SubFieldQuery) Fields(fields ...FieldQueryString) FieldQueryString {
 	query, _ := Marshal(map[string][]FieldQueryString{q.name: fields})
 	return FieldQueryString(query)
 }
+
+// ExcludeField builds the field query excluding the field.
+// The name can be a dot separated path to exclude a nested field (e.g. "internal.audit").
+// If a query has no selecting fields but excluding ones, all the other fields are kept.
+// The same is written as {"-":"name"} in FieldQueryString.
+func ExcludeField(name string) FieldQueryString {
+	query, _ := Marshal(map[string]string{"-": name})
+	return FieldQueryString(query)
+}
+
+// ParseFieldSelector builds FieldQuery from the field selector string in the style of partial response,
+// which is used by REST APIs as `?fields=id,name,friends(id,name)`.
+//
+//	selector = field *( "," field )
+//	field    = [ "-" ] name [ "/" field ] [ "(" selector ")" ]
+//
+// "a/b" is the same as "a(b)", and a field prefixed with "-" is excluded instead of selected.
+// The returned error is *SyntaxError, which has the offset of the invalid part.
+// Use FieldQuery.Validate to reject the names that don't exist in a type before encoding.
+func ParseFieldSelector(s string) (*FieldQuery, error) {
+	return encoder.ParseFieldSelector(s)
+}
@@ -2,7 +2,9 @@ package json_test
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/goccy/go-json"
@@ -119,3 +121,317 @@ func TestFieldQuery(t *testing.T) {
 		t.Fatalf("failed to encode with field query: expected %q but got %q", expected, got)
 	}
 }
+
+func TestFieldQueryUnmarshal(t *testing.T) {
+	query, err := json.BuildFieldQuery(
+		"XA",
+		json.BuildSubFieldQuery("XC").Fields(
+			"YB",
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := json.SetFieldQueryToContext(context.Background(), query)
+	src := `{"XA":1,"XB":"xb","XC":{"YA":2,"YB":"yb","YC":{"ZA":"za"},"YD":true},"XD":true,"XE":5}`
+	expected := queryTestX{XA: 1, XC: &queryTestY{YB: "yb"}}
+	t.Run("UnmarshalContext", func(t *testing.T) {
+		var v queryTestX
+		if err := json.UnmarshalContext(ctx, []byte(src), &v); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, expected) {
+			t.Fatalf("failed to decode with field query: expected %+v but got %+v", expected, v)
+		}
+	})
+	t.Run("DecodeContext", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.DisallowUnknownFields()
+		var v queryTestX
+		if err := dec.DecodeContext(ctx, &v); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, expected) {
+			t.Fatalf("failed to decode with field query: expected %+v but got %+v", expected, v)
+		}
+	})
+	t.Run("slice", func(t *testing.T) {
+		var v []*queryTestX
+		if err := json.UnmarshalContext(ctx, []byte("["+src+","+src+"]"), &v); err != nil {
+			t.Fatal(err)
+		}
+		if len(v) != 2 || !reflect.DeepEqual(*v[0], expected) || !reflect.DeepEqual(*v[1], expected) {
+			t.Fatalf("failed to decode with field query: %+v", v)
+		}
+	})
+	t.Run("without query", func(t *testing.T) {
+		var v queryTestX
+		if err := json.UnmarshalContext(context.Background(), []byte(src), &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.XB != "xb" || v.XC.YA != 2 || v.XC.YC.ZA != "za" {
+			t.Fatalf("failed to decode without field query: %+v", v)
+		}
+	})
+}
+
+func TestFieldQueryExclude(t *testing.T) {
+	v := &queryTestX{
+		XA: 1,
+		XB: "xb",
+		XC: &queryTestY{
+			YA: 2,
+			YB: "yb",
+			YC: &queryTestZ{
+				ZA: "za",
+				ZB: true,
+				ZC: 3,
+			},
+			YD: true,
+			YE: 4,
+		},
+		XD: true,
+		XE: 5,
+	}
+	tests := []struct {
+		name     string
+		fields   []json.FieldQueryString
+		expected string
+	}{
+		{
+			name:     "top level",
+			fields:   []json.FieldQueryString{json.ExcludeField("XB"), `{"-":"XD"}`},
+			expected: `{"XA":1,"XC":{"YA":2,"YB":"yb","YC":{"ZA":"za","ZB":true,"ZC":3},"YD":true,"YE":4},"XE":5}`,
+		},
+		{
+			name:     "nested path",
+			fields:   []json.FieldQueryString{json.ExcludeField("XC.YC.ZB"), json.ExcludeField("XC.YD")},
+			expected: `{"XA":1,"XB":"xb","XC":{"YA":2,"YB":"yb","YC":{"ZA":"za","ZC":3},"YE":4},"XD":true,"XE":5}`,
+		},
+		{
+			name:     "sub field query",
+			fields:   []json.FieldQueryString{json.BuildSubFieldQuery("XC").Fields(json.ExcludeField("YC"))},
+			expected: `{"XC":{"YA":2,"YB":"yb","YD":true,"YE":4}}`,
+		},
+		{
+			name:     "with selecting fields",
+			fields:   []json.FieldQueryString{"XA", "XC", json.ExcludeField("XC.YC")},
+			expected: `{"XA":1,"XC":{"YA":2,"YB":"yb","YD":true,"YE":4}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := json.BuildFieldQuery(test.fields...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := json.SetFieldQueryToContext(context.Background(), query)
+			b, err := json.MarshalContext(ctx, v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expected {
+				t.Fatalf("failed to encode with field query: expected %q but got %q", test.expected, string(b))
+			}
+			full, err := json.MarshalContext(context.Background(), v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded queryTestX
+			if err := json.UnmarshalContext(ctx, full, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			b, err = json.MarshalContext(ctx, &decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expected {
+				t.Fatalf("failed to decode with field query: expected %q but got %q", test.expected, string(b))
+			}
+		})
+	}
+	t.Run("query string", func(t *testing.T) {
+		query, err := json.FieldQueryString(`[{"-":"XB"},{"XC":[{"-":"YA"}]}]`).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(query, &json.FieldQuery{
+			Fields: []*json.FieldQuery{
+				{Name: "XB", Exclude: true},
+				{Name: "XC", Fields: []*json.FieldQuery{{Name: "YA", Exclude: true}}},
+			},
+		}) {
+			t.Fatal("cannot get query")
+		}
+		queryStr, err := query.QueryString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if queryStr != `[{"-":"XB"},{"XC":[{"-":"YA"}]}]` {
+			t.Fatalf("failed to create query string. %s", queryStr)
+		}
+	})
+	t.Run("dash prefixed name", func(t *testing.T) {
+		v := struct {
+			Dash  int `json:"-dash"`
+			Other int `json:"other"`
+		}{Dash: 1, Other: 2}
+		query, err := json.BuildFieldQuery("-dash")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != `{"-dash":1}` {
+			t.Fatalf("failed to select the field: %s", b)
+		}
+	})
+}
+
+type fieldSelectorTestUser struct {
+	ID      int                      `json:"id"`
+	Name    string                   `json:"name"`
+	Email   string                   `json:"email"`
+	Friends []*fieldSelectorTestUser `json:"friends,omitempty"`
+}
+
+type FieldSelectorTestBase struct {
+	ID int `json:"id"`
+}
+
+type fieldSelectorTestMember struct {
+	FieldSelectorTestBase
+	Role string `json:"role"`
+}
+
+func TestParseFieldSelector(t *testing.T) {
+	t.Run("grammar", func(t *testing.T) {
+		tests := []struct {
+			selector string
+			expected string
+		}{
+			{selector: "id", expected: `["id"]`},
+			{selector: "id,name,friends(id,name)", expected: `["id","name",{"friends":["id","name"]}]`},
+			{selector: "id, friends( id , friends(id) )", expected: `["id",{"friends":["id",{"friends":["id"]}]}]`},
+			{selector: "friends/id,name", expected: `[{"friends":["id"]},"name"]`},
+			{selector: "friends/friends(id,name)", expected: `[{"friends":[{"friends":["id","name"]}]}]`},
+			{selector: "-email,-friends/email", expected: `[{"-":"email"},{"-":"friends.email"}]`},
+		}
+		for _, test := range tests {
+			query, err := json.ParseFieldSelector(test.selector)
+			if err != nil {
+				t.Fatalf("%s: %v", test.selector, err)
+			}
+			queryStr, err := query.QueryString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(queryStr) != test.expected {
+				t.Fatalf("%s: expected %s but got %s", test.selector, test.expected, queryStr)
+			}
+		}
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		tests := []struct {
+			selector string
+			offset   int64
+		}{
+			{selector: "", offset: 0},
+			{selector: "id,", offset: 3},
+			{selector: "id,,name", offset: 3},
+			{selector: "friends(id", offset: 10},
+			{selector: "friends()", offset: 8},
+			{selector: "id)", offset: 2},
+			{selector: "-friends(id)", offset: 8},
+		}
+		for _, test := range tests {
+			_, err := json.ParseFieldSelector(test.selector)
+			if err == nil {
+				t.Fatalf("%q: expected error", test.selector)
+			}
+			var syntaxErr *json.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("%q: unexpected error type %T", test.selector, err)
+			}
+			if syntaxErr.Offset != test.offset {
+				t.Fatalf("%q: expected offset %d but got %d: %v", test.selector, test.offset, syntaxErr.Offset, err)
+			}
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		typ := reflect.TypeOf(fieldSelectorTestUser{})
+		for _, selector := range []string{"id,name", "friends(id,friends(name))", "-friends/email"} {
+			query, err := json.ParseFieldSelector(selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := query.Validate(typ); err != nil {
+				t.Fatalf("%s: %v", selector, err)
+			}
+		}
+		for _, selector := range []string{"nmae", "friends(id,emial)", "-friends/mail", "id(name)"} {
+			query, err := json.ParseFieldSelector(selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := query.Validate(typ); err == nil {
+				t.Fatalf("%s: expected validation error", selector)
+			}
+		}
+	})
+	t.Run("embedded", func(t *testing.T) {
+		v := &fieldSelectorTestMember{
+			FieldSelectorTestBase: FieldSelectorTestBase{ID: 1},
+			Role:                  "admin",
+		}
+		typ := reflect.TypeOf(v)
+		tests := []struct {
+			selector string
+			valid    bool
+			expected string
+		}{
+			{selector: "FieldSelectorTestBase,role", valid: true, expected: `{"id":1,"role":"admin"}`},
+			{selector: "id,role", valid: false, expected: `{"role":"admin"}`},
+		}
+		for _, test := range tests {
+			query, err := json.ParseFieldSelector(test.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := query.Validate(typ); (err == nil) != test.valid {
+				t.Fatalf("%s: unexpected validation result %v", test.selector, err)
+			}
+			b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expected {
+				t.Fatalf("%s: expected %s but got %s", test.selector, test.expected, b)
+			}
+		}
+	})
+	t.Run("encode", func(t *testing.T) {
+		query, err := json.ParseFieldSelector("id,name,friends(id,name)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := json.SetFieldQueryToContext(context.Background(), query)
+		b, err := json.MarshalContext(ctx, &fieldSelectorTestUser{
+			ID:    1,
+			Name:  "alice",
+			Email: "alice@example.com",
+			Friends: []*fieldSelectorTestUser{
+				{ID: 2, Name: "bob", Email: "bob@example.com"},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"id":1,"name":"alice","friends":[{"id":2,"name":"bob"}]}`
+		if string(b) != expected {
+			t.Fatalf("expected %s but got %s", expected, b)
+		}
+	})
+}
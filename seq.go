@@ -0,0 +1,203 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// A RecordError describes the error of decoding a record by SeqDecoder.
+// The following records can still be decoded after it.
+type RecordError struct {
+	Record int   // the record number starting from 1
+	Err    error // the error of decoding the record
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("json: record %d: %s", e.Record, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// A SeqDecoder reads JSON text sequences ( RFC 7464, application/json-seq ) from an input stream.
+// Each record begins with RS ( 0x1E ) and contains one JSON value.
+type SeqDecoder struct {
+	s        *decoder.Stream
+	record   int
+	optFuncs []DecodeOptionFunc
+}
+
+// NewSeqDecoder returns a new decoder that reads JSON text sequences from r.
+// The options are applied to all the decoding by the decoder like SetOptions.
+func NewSeqDecoder(r io.Reader, optFuncs ...DecodeOptionFunc) *SeqDecoder {
+	return &SeqDecoder{
+		s:        decoder.NewStream(r),
+		optFuncs: optFuncs,
+	}
+}
+
+// SetOptions sets the options applied to all the subsequent decoding by the decoder.
+// The options passed to DecodeWithOption are applied after them.
+func (d *SeqDecoder) SetOptions(optFuncs ...DecodeOptionFunc) {
+	d.optFuncs = optFuncs
+}
+
+// Record returns the number of the record read last.
+func (d *SeqDecoder) Record() int {
+	return d.record
+}
+
+// Decode reads the next record and stores the JSON value in it to the value pointed to by v.
+// It returns io.EOF at the end of the input.
+//
+// If the record is truncated or invalid, *RecordError is returned and the record is dropped,
+// so the next call continues from the following record as RFC 7464 requires.
+// The empty records made by the consecutive RS characters are skipped.
+func (d *SeqDecoder) Decode(v interface{}) error {
+	return d.DecodeWithOption(v)
+}
+
+// DecodeContext calls Decode with context.Context.
+// Reading the input is aborted when ctx is done in the same way as Decoder.DecodeContext.
+func (d *SeqDecoder) DecodeContext(ctx context.Context, v interface{}, optFuncs ...DecodeOptionFunc) error {
+	d.s.SetContext(ctx)
+	record, err := d.readRecord()
+	if err != nil {
+		return err
+	}
+	return d.recordError(unmarshalContext(ctx, record, v, d.options(optFuncs)...))
+}
+
+// DecodeWithOption calls Decode with DecodeOption.
+func (d *SeqDecoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	record, err := d.readRecord()
+	if err != nil {
+		return err
+	}
+	return d.recordError(unmarshal(record, v, d.options(optFuncs)...))
+}
+
+func (d *SeqDecoder) readRecord() ([]byte, error) {
+	for {
+		record, err := d.s.ReadRecord()
+		if err != nil {
+			return nil, err
+		}
+		text := bytes.TrimLeft(record, " \t\r\n")
+		if len(text) == 0 {
+			continue
+		}
+		d.record++
+		// a number, true, false or null can be truncated without being invalid,
+		// so it must be followed by a white space as RFC 7464 describes.
+		switch text[0] {
+		case '{', '[', '"':
+		default:
+			switch text[len(text)-1] {
+			case ' ', '\t', '\r', '\n':
+			default:
+				return nil, d.recordError(errors.ErrSyntax("unexpected end of record", int64(len(text))))
+			}
+		}
+		return text, nil
+	}
+}
+
+func (d *SeqDecoder) options(optFuncs []DecodeOptionFunc) []DecodeOptionFunc {
+	if len(optFuncs) == 0 {
+		return d.optFuncs
+	}
+	return append(append([]DecodeOptionFunc{}, d.optFuncs...), optFuncs...)
+}
+
+func (d *SeqDecoder) recordError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RecordError{Record: d.record, Err: err}
+}
+
+// A SeqEncoder writes JSON text sequences ( RFC 7464, application/json-seq ) to an output stream.
+// Each value is written as a record prefixed with RS ( 0x1E ) and terminated by LF, and the record is written by one call to Write.
+// It's safe to call the encoding methods concurrently, and the records written by them are never interleaved.
+type SeqEncoder struct {
+	enc *Encoder
+	mu  sync.Mutex
+}
+
+// NewSeqEncoder returns a new encoder that writes JSON text sequences to w.
+// The options are applied to all the encoding by the encoder like SetOptions.
+func NewSeqEncoder(w io.Writer, optFuncs ...EncodeOptionFunc) *SeqEncoder {
+	return &SeqEncoder{enc: NewEncoder(w, optFuncs...)}
+}
+
+// SetOptions sets the options applied to all the subsequent encoding by the encoder.
+// It must not be called concurrently with the encoding methods.
+func (e *SeqEncoder) SetOptions(optFuncs ...EncodeOptionFunc) {
+	e.enc.SetOptions(optFuncs...)
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings like Encoder.SetEscapeHTML.
+// It must not be called concurrently with the encoding methods.
+func (e *SeqEncoder) SetEscapeHTML(on bool) {
+	e.enc.SetEscapeHTML(on)
+}
+
+// SetIndent instructs the encoder to indent each record like Encoder.SetIndent.
+// It must not be called concurrently with the encoding methods.
+func (e *SeqEncoder) SetIndent(prefix, indent string) {
+	e.enc.SetIndent(prefix, indent)
+}
+
+// Encode writes the JSON encoding of v as a record.
+func (e *SeqEncoder) Encode(v interface{}) error {
+	return e.EncodeWithOption(v)
+}
+
+// EncodeWithOption call Encode with EncodeOption.
+func (e *SeqEncoder) EncodeWithOption(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+
+	err := e.encodeWithOption(ctx, v, optFuncs...)
+
+	encoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+// EncodeContext call Encode with context.Context and EncodeOption.
+func (e *SeqEncoder) EncodeContext(ctx context.Context, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	rctx := encoder.TakeRuntimeContext()
+	rctx.Option.Flag = 0
+	rctx.Option.Flag |= encoder.ContextOption
+	rctx.Option.Context = ctx
+
+	err := e.encodeWithOption(rctx, v, optFuncs...) //nolint: contextcheck
+
+	encoder.ReleaseRuntimeContext(rctx)
+	return err
+}
+
+func (e *SeqEncoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	e.enc.setOption(ctx, optFuncs...)
+	buf, err := encodeWithIndentOption(ctx, v)
+	if err != nil {
+		return err
+	}
+	record := append(ctx.MarshalBuf[:0], decoder.RecordSeparator)
+	record = append(record, trimEncoded(ctx, buf)...)
+	record = append(record, '\n')
+	ctx.MarshalBuf = record
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = (*encoderWriter)(e.enc).Write(record)
+	return err
+}
@@ -0,0 +1,85 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goccy/go-json"
+)
+
+func TestSeqDecoder(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		src := "garbage\x1e{\"a\":1}\n\x1e\x1e[1,\n 2]\n\x1e\"s\"\x1e12\n\x1etrue\n"
+		dec := json.NewSeqDecoder(iotest.OneByteReader(strings.NewReader(src)))
+		var got []interface{}
+		for {
+			var v interface{}
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			got = append(got, v)
+		}
+		assertEq(t, "records", 5, dec.Record())
+		b, err := json.Marshal(got)
+		assertErr(t, err)
+		assertEq(t, "values", `[{"a":1},[1,2],"s",12,true]`, string(b))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		src := "\x1e{\"a\":\x1e{\"a\":1}\n\x1e12\x1etru\x1e3\n\x1e[1,2"
+		dec := json.NewSeqDecoder(strings.NewReader(src))
+		var (
+			values   []interface{}
+			failures []int
+		)
+		for {
+			var v interface{}
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			var recordErr *json.RecordError
+			if errors.As(err, &recordErr) {
+				if recordErr.Record == 4 {
+					assertEq(t, "message", "json: record 4: unexpected end of record", err.Error())
+				}
+				failures = append(failures, recordErr.Record)
+				continue
+			}
+			assertErr(t, err)
+			values = append(values, v)
+		}
+		b, err := json.Marshal(values)
+		assertErr(t, err)
+		assertEq(t, "values", `[{"a":1},3]`, string(b))
+		assertEq(t, "failures", "[1 3 4 6]", fmt.Sprint(failures))
+	})
+}
+
+func TestSeqEncoder(t *testing.T) {
+	w := &recordWriter{}
+	enc := json.NewSeqEncoder(w)
+	assertErr(t, enc.Encode(map[string]int{"a": 1}))
+	assertErr(t, enc.Encode(12))
+	enc.SetIndent("", " ")
+	assertErr(t, enc.Encode([]int{1}))
+	assertEq(t, "records", fmt.Sprintf("%q", []string{"\x1e{\"a\":1}\n", "\x1e12\n", "\x1e[\n 1\n]\n"}), fmt.Sprintf("%q", w.writes))
+
+	// the records are read back by SeqDecoder.
+	dec := json.NewSeqDecoder(bytes.NewBufferString(w.String()))
+	var (
+		m map[string]int
+		n int
+		s []int
+	)
+	assertErr(t, dec.Decode(&m))
+	assertErr(t, dec.Decode(&n))
+	assertErr(t, dec.Decode(&s))
+	assertEq(t, "decoded", "map[a:1] 12 [1]", fmt.Sprint(m, " ", n, " ", s))
+}
@@ -18,6 +18,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goccy/go-json"
 )
@@ -539,3 +540,258 @@ func TestIssue278(t *testing.T) {
 		t.Errorf("string %q; want = %q", got, want)
 	}
 }
+
+func TestDecoderDecodeEach(t *testing.T) {
+	type T struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	src := `{"total": 3, "items": [{"id":1,"name":"a"}, {"id":2,"name":"b\n"} ,{"id":3,"name":"c"}], "meta": {"x": [1, {"y": 2}]}, "empty": [], "null": null}`
+	for _, name := range []string{"buffered", "one byte"} {
+		t.Run(name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(src)
+			if name == "one byte" {
+				r = iotest.OneByteReader(r)
+			}
+			dec := json.NewDecoder(r)
+			var (
+				keys  []string
+				items []T
+				total int
+			)
+			err := dec.DecodeObjectEach(func(key string, dec *json.Decoder) error {
+				keys = append(keys, key)
+				switch key {
+				case "total":
+					return dec.Decode(&total)
+				case "items":
+					return dec.DecodeArrayEach(func(i int, dec *json.Decoder) error {
+						if i != len(items) {
+							t.Fatalf("unexpected index %d", i)
+						}
+						var v T
+						if err := dec.Decode(&v); err != nil {
+							return err
+						}
+						items = append(items, v)
+						return nil
+					})
+				case "empty", "null":
+					return dec.DecodeArrayEach(func(i int, dec *json.Decoder) error {
+						t.Fatalf("unexpected element %d", i)
+						return nil
+					})
+				}
+				// meta is skipped without being read.
+				return nil
+			})
+			assertErr(t, err)
+			assertEq(t, "each", "total,items,meta,empty,null", strings.Join(keys, ","))
+			assertEq(t, "each", 3, total)
+			assertEq(t, "each", fmt.Sprint([]T{{1, "a"}, {2, "b\n"}, {3, "c"}}), fmt.Sprint(items))
+			if err := dec.DecodeArrayEach(func(int, *json.Decoder) error { return nil }); err != io.EOF {
+				t.Fatalf("expected io.EOF but got %v", err)
+			}
+		})
+	}
+	t.Run("error", func(t *testing.T) {
+		expected := fmt.Errorf("stop")
+		dec := json.NewDecoder(strings.NewReader(`[1, 2, 3]`))
+		err := dec.DecodeArrayEach(func(i int, dec *json.Decoder) error {
+			if i == 1 {
+				return expected
+			}
+			return nil
+		})
+		if err != expected {
+			t.Fatalf("expected %v but got %v", expected, err)
+		}
+		for _, src := range []string{`{"a": 1}`, `[1,]`, `[1 2]`, `[1`} {
+			dec := json.NewDecoder(strings.NewReader(src))
+			if err := dec.DecodeArrayEach(func(int, *json.Decoder) error { return nil }); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+		for _, src := range []string{`[1]`, `{"a" 1}`, `{"a": 1 "b": 2}`, `{1: 1}`} {
+			dec := json.NewDecoder(strings.NewReader(src))
+			if err := dec.DecodeObjectEach(func(string, *json.Decoder) error { return nil }); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+	})
+}
+
+func TestDecoderSkipValue(t *testing.T) {
+	src := `{"type": "event", "payload": {"a": [1, "x\"y", {"b": null}]}} [true, false] "s" 12.5e3 null`
+	for _, name := range []string{"buffered", "one byte"} {
+		t.Run(name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(src)
+			if name == "one byte" {
+				r = iotest.OneByteReader(r)
+			}
+			dec := json.NewDecoder(r)
+			var (
+				typ     string
+				payload json.RawMessage
+			)
+			err := dec.DecodeObjectEach(func(key string, dec *json.Decoder) error {
+				switch key {
+				case "type":
+					return dec.Decode(&typ)
+				case "payload":
+					raw, err := dec.ReadRawValue()
+					payload = raw
+					return err
+				}
+				return dec.SkipValue()
+			})
+			assertErr(t, err)
+			assertEq(t, "type", "event", typ)
+			assertEq(t, "payload", `{"a": [1, "x\"y", {"b": null}]}`, string(payload))
+			assertErr(t, dec.SkipValue())
+			raw, err := dec.ReadRawValue()
+			assertErr(t, err)
+			assertEq(t, "raw", `"s"`, string(raw))
+			assertErr(t, dec.SkipValue())
+			raw, err = dec.ReadRawValue()
+			assertErr(t, err)
+			assertEq(t, "raw", `null`, string(raw))
+			if err := dec.SkipValue(); err != io.EOF {
+				t.Fatalf("expected io.EOF but got %v", err)
+			}
+			if _, err := dec.ReadRawValue(); err != io.EOF {
+				t.Fatalf("expected io.EOF but got %v", err)
+			}
+		})
+	}
+	t.Run("error", func(t *testing.T) {
+		for _, src := range []string{`[1, 2`, `{"a": "b`} {
+			if err := json.NewDecoder(strings.NewReader(src)).SkipValue(); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+			if _, err := json.NewDecoder(strings.NewReader(src)).ReadRawValue(); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+	})
+}
+
+func TestDecoderTokenReader(t *testing.T) {
+	src := `{"name": "go-json", "tags": ["a", "b\tc"], "rate": -1.5e2, "ok": true, "ng": false, "none": null} 10`
+	for _, name := range []string{"buffered", "one byte"} {
+		t.Run(name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(src)
+			if name == "one byte" {
+				r = iotest.OneByteReader(r)
+			}
+			dec := json.NewDecoder(r)
+			var got []string
+			for {
+				kind := dec.PeekKind()
+				if kind == json.InvalidKind {
+					break
+				}
+				switch kind {
+				case json.ObjectStartKind, json.ArrayStartKind, json.ObjectEndKind, json.ArrayEndKind:
+					delim, err := dec.ReadDelim()
+					assertErr(t, err)
+					got = append(got, fmt.Sprintf("%s:%d", delim, dec.Depth()))
+				case json.StringKind:
+					s, err := dec.ReadString()
+					assertErr(t, err)
+					got = append(got, strconv.Quote(string(s)))
+				case json.NumberKind:
+					n, err := dec.ReadNumber()
+					assertErr(t, err)
+					got = append(got, string(n))
+				case json.BoolKind:
+					b, err := dec.ReadBool()
+					assertErr(t, err)
+					got = append(got, strconv.FormatBool(b))
+				case json.NullKind:
+					assertErr(t, dec.ReadNull())
+					got = append(got, "null")
+				}
+			}
+			assertEq(t, "tokens",
+				`{:1 "name" "go-json" "tags" [:2 "a" "b\tc" ]:1 "rate" -1.5e2 "ok" true "ng" false "none" null }:0 10`,
+				strings.Join(got, " "),
+			)
+			if _, err := dec.ReadString(); err != io.EOF {
+				t.Fatalf("expected io.EOF but got %v", err)
+			}
+		})
+	}
+	t.Run("error", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`[1]`))
+		if _, err := dec.ReadString(); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := dec.ReadDelim(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dec.ReadBool(); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := json.NewDecoder(strings.NewReader(`]`)).ReadDelim(); err == nil {
+			t.Fatal("expected error")
+		}
+		for _, src := range []string{`-`, `01`, `1.`, `-.5`, `1e`, `1e+`, `1.e5`, `+1`, `1-2`} {
+			if _, err := json.NewDecoder(strings.NewReader(src)).ReadNumber(); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+		for _, src := range []string{`0`, `-0.5`, `1e400`, `1E-7`, `12.5e+3`} {
+			n, err := json.NewDecoder(strings.NewReader(src)).ReadNumber()
+			assertErr(t, err)
+			assertEq(t, "number", src, string(n))
+		}
+		for _, src := range []string{`[}`, `{]`, `[{]`} {
+			dec := json.NewDecoder(strings.NewReader(src))
+			var err error
+			for err == nil {
+				_, err = dec.ReadDelim()
+			}
+			if err == io.EOF {
+				t.Fatalf("expected error for %s", src)
+			}
+		}
+		if kind := json.NewDecoder(strings.NewReader(`x`)).PeekKind(); kind != json.InvalidKind {
+			t.Fatalf("unexpected kind %s", kind)
+		}
+	})
+	t.Run("allocs", func(t *testing.T) {
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i := 0; i < 10000; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(`"value", 12345, true, null`)
+		}
+		buf.WriteByte(']')
+		src := buf.Bytes()
+		r := bytes.NewReader(src)
+		dec := json.NewDecoder(r)
+		if _, err := dec.ReadDelim(); err != nil {
+			t.Fatal(err)
+		}
+		allocs := testing.AllocsPerRun(1000, func() {
+			if _, err := dec.ReadString(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := dec.ReadNumber(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := dec.ReadBool(); err != nil {
+				t.Fatal(err)
+			}
+			if err := dec.ReadNull(); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs != 0 {
+			t.Fatalf("expected no allocations but got %v", allocs)
+		}
+	})
+}
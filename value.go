@@ -0,0 +1,50 @@
+package json
+
+import (
+	"github.com/goccy/go-json/internal/decoder"
+)
+
+// Value is a mutable JSON value (DOM).
+// The values are looked up by Get with the object keys and the array indexes, and are read by the typed accessors:
+//
+//	port, err := v.Get("servers", 0, "port").Int()
+//
+// The object keeps the order of the members, and the number keeps its literal, so a document is round-tripped as it is.
+// Unmarshal and Decoder decode JSON into Value directly without making map[string]interface{},
+// and Marshal encodes Value directly.
+// The zero Value is null, and the nil *Value represents the value that doesn't exist.
+type Value = decoder.Value
+
+// ValueKind is the kind of Value.
+type ValueKind = decoder.ValueKind
+
+const (
+	InvalidValue = decoder.InvalidValue
+	NullValue    = decoder.NullValue
+	BoolValue    = decoder.BoolValue
+	NumberValue  = decoder.NumberValue
+	StringValue  = decoder.StringValue
+	ArrayValue   = decoder.ArrayValue
+	ObjectValue  = decoder.ObjectValue
+)
+
+// NewObject returns a new empty object Value.
+func NewObject() *Value {
+	return decoder.NewObject()
+}
+
+// NewArray returns a new empty array Value.
+func NewArray() *Value {
+	return decoder.NewArray()
+}
+
+// ValueOf converts v into Value.
+// *Value is returned as it is, and the other values are converted through their JSON encoding.
+func ValueOf(v interface{}) (*Value, error) {
+	return decoder.ValueOf(v)
+}
+
+// ParseValue decodes data into Value.
+func ParseValue(data []byte) (*Value, error) {
+	return decoder.ParseValue(data)
+}
@@ -0,0 +1,297 @@
+package json_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goccy/go-json"
+)
+
+func TestValue(t *testing.T) {
+	src := `{"servers": [{"host": "a<b>", "port": 8080, "tls": true}, {"host": "c", "port": 12345678901234567890}], "z": null, "a": 1.50}`
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v json.Value
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		assertEq(t, "kind", json.ObjectValue, v.Kind())
+		assertEq(t, "keys", `["servers" "z" "a"]`, fmt.Sprintf("%q", v.Keys()))
+		port, err := v.Get("servers", 0, "port").Int()
+		assertErr(t, err)
+		assertEq(t, "int", int64(8080), port)
+		big, err := v.Get("servers", 1, "port").Uint()
+		assertErr(t, err)
+		assertEq(t, "uint", uint64(12345678901234567890), big)
+		tls, err := v.Get("servers", 0, "tls").Bool()
+		assertErr(t, err)
+		assertEq(t, "bool", true, tls)
+		host, err := v.Get("servers", 0, "host").Str()
+		assertErr(t, err)
+		assertEq(t, "string", "a<b>", host)
+		f, err := v.Get("a").Float()
+		assertErr(t, err)
+		assertEq(t, "float", 1.5, f)
+		assertEq(t, "null", true, v.Get("z").IsNull())
+		assertEq(t, "length", 2, v.Get("servers").Len())
+
+		got, err := json.Marshal(&v)
+		assertErr(t, err)
+		assertEq(t, "marshal", `{"servers":[{"host":"a\u003cb\u003e","port":8080,"tls":true},{"host":"c","port":12345678901234567890}],"z":null,"a":1.50}`, string(got))
+		got, err = json.MarshalWithOption(v, json.DisableHTMLEscape())
+		assertErr(t, err)
+		assertEq(t, "no escape", `{"servers":[{"host":"a<b>","port":8080,"tls":true},{"host":"c","port":12345678901234567890}],"z":null,"a":1.50}`, string(got))
+		got, err = json.MarshalIndent(v.Get("servers", 1), "", " ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"host\": \"c\",\n \"port\": 12345678901234567890\n}", string(got))
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v json.Value
+		dec := json.NewDecoder(iotest.OneByteReader(strings.NewReader(src + ` "x\n" [] {}`)))
+		assertErr(t, dec.Decode(&v))
+		host, err := v.Get("servers", 0, "host").Str()
+		assertErr(t, err)
+		assertEq(t, "string", "a<b>", host)
+		assertEq(t, "encoded", `{"servers":[{"host":"a<b>","port":8080,"tls":true},{"host":"c","port":12345678901234567890}],"z":null,"a":1.50}`, v.String())
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "escaped string", `"x\n"`, v.String())
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "empty array", `[]`, v.String())
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "empty object", `{}`, v.String())
+	})
+	t.Run("Field", func(t *testing.T) {
+		var v struct {
+			A json.Value  `json:"a"`
+			B *json.Value `json:"b"`
+			C *json.Value `json:"c"`
+			D *json.Value `json:"d"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"a": [1, "x"], "b": {"k": false}, "c": null}`), &v))
+		assertEq(t, "a", `[1,"x"]`, v.A.String())
+		assertEq(t, "b", `{"k":false}`, v.B.String())
+		assertEq(t, "c", true, v.C == nil)
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "marshal", `{"a":[1,"x"],"b":{"k":false},"c":null,"d":null}`, string(got))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		v, err := json.ParseValue([]byte(`{"a": [1]}`))
+		assertErr(t, err)
+		missing := v.Get("a", 1, "b")
+		assertEq(t, "exists", false, missing.Exists())
+		assertEq(t, "kind", json.InvalidValue, missing.Kind())
+		assertEq(t, "wrong selector", false, v.Get(1.5).Exists())
+		if _, err := missing.Int(); err == nil || err.Error() != "json: cannot unmarshal missing value into Go value of type int64" {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if _, err := v.Get("a", 0).Str(); err == nil || err.Error() != "json: cannot unmarshal number 1 into Go value of type string" {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if _, err := json.ParseValue([]byte(`{"a": 1.5}`)); err != nil {
+			t.Fatal(err)
+		}
+		fv, _ := json.ParseValue([]byte(`1.5`))
+		if _, err := fv.Int(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, src := range []string{`{"a" 1}`, `{"a": 1,}`, `[1 2]`, `{1: 2}`, `tru`, `"a`, `[1] x`, ``} {
+			if _, err := json.ParseValue([]byte(src)); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+			var v json.Value
+			// the stream doesn't detect the truncated literal in the same way as interface{}.
+			if src == `[1] x` || src == `tru` {
+				continue
+			}
+			if err := json.NewDecoder(strings.NewReader(src)).Decode(&v); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+		}
+	})
+}
+
+func TestValueEdit(t *testing.T) {
+	v, err := json.ParseValue([]byte(`{"b": 1, "a": [1, 2, 3]}`))
+	assertErr(t, err)
+	assertErr(t, v.Set("b", map[string]int{"x": 1}))
+	assertErr(t, v.Set("c", "s"))
+	assertErr(t, v.Get("a").Append(4, nil, json.NewObject()))
+	assertErr(t, v.Get("a").SetIndex(0, true))
+	assertEq(t, "delete index", true, v.Get("a").DeleteIndex(1))
+	assertEq(t, "delete", true, v.Delete("c"))
+	assertEq(t, "delete missing", false, v.Delete("c"))
+	assertEq(t, "edited", `{"b":{"x":1},"a":[true,3,4,null,{}]}`, v.String())
+
+	if err := v.Get("b").Append(1); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := v.Get("a").Set("k", 1); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := v.Get("a").SetIndex(5, 1); err == nil {
+		t.Fatal("expected error")
+	}
+
+	cloned := v.Clone()
+	assertErr(t, cloned.Get("b").Set("x", 2))
+	assertEq(t, "original", `{"b":{"x":1},"a":[true,3,4,null,{}]}`, v.String())
+	assertEq(t, "cloned", `{"b":{"x":2},"a":[true,3,4,null,{}]}`, cloned.String())
+
+	var members []string
+	v.Each(func(key string, value *json.Value) bool {
+		members = append(members, key+"="+value.String())
+		return true
+	})
+	assertEq(t, "each", `b={"x":1},a=[true,3,4,null,{}]`, strings.Join(members, ","))
+	var elems []string
+	v.Get("a").EachElem(func(i int, value *json.Value) bool {
+		elems = append(elems, fmt.Sprintf("%d:%s", i, value))
+		return i < 1
+	})
+	assertEq(t, "each elem", `0:true,1:3`, strings.Join(elems, ","))
+
+	obj := json.NewObject()
+	assertErr(t, obj.Set("v", v.Get("b")))
+	assertErr(t, obj.Set("n", 1.25))
+	assertEq(t, "new object", `{"v":{"x":1},"n":1.25}`, obj.String())
+	assertEq(t, "interface", `map[n:1.25 v:map[x:1]]`, fmt.Sprint(obj.Interface()))
+
+	var zero json.Value
+	assertEq(t, "zero", json.NullValue, zero.Kind())
+	assertEq(t, "zero string", `null`, zero.String())
+}
+
+func TestValuePath(t *testing.T) {
+	v, err := json.ParseValue([]byte(`{"items": [{"id": 1, "tags": ["a"]}, {"name": "x"}, {"id": 3}]}`))
+	assertErr(t, err)
+	t.Run("Values", func(t *testing.T) {
+		path, err := json.CreatePath("$.items[*].id")
+		assertErr(t, err)
+		values, err := path.Values(v)
+		assertErr(t, err)
+		assertEq(t, "length", 2, len(values))
+		assertEq(t, "first", "1", values[0].String())
+		assertEq(t, "second", "3", values[1].String())
+
+		// the matched values refer to the values in v.
+		items, err := json.CreatePath("$.items[0].tags")
+		assertErr(t, err)
+		tags, err := items.Values(v)
+		assertErr(t, err)
+		assertEq(t, "tags", 1, len(tags))
+		assertErr(t, tags[0].Append("b"))
+		assertEq(t, "edited", `["a","b"]`, v.Get("items", 0, "tags").String())
+	})
+	t.Run("RFC9535", func(t *testing.T) {
+		path, err := json.CreatePath("$.items[?@.id > 1]", json.PathRFC9535())
+		assertErr(t, err)
+		values, err := path.Values(v)
+		assertErr(t, err)
+		assertEq(t, "length", 1, len(values))
+		assertEq(t, "same value", true, values[0] == v.Get("items", 2))
+		path, err = json.CreatePath("$..tags[0]", json.PathRFC9535())
+		assertErr(t, err)
+		values, err = path.Values(v)
+		assertErr(t, err)
+		assertEq(t, "recursive", `"a"`, values[0].String())
+	})
+	t.Run("same as ExtractWithLocation", func(t *testing.T) {
+		src := []byte(v.String())
+		for _, p := range []string{"$", "$.items[*].id", "$.items[0].tags[*]", "$..id", "$..tags", "$.items[1].name", "$.items[5]"} {
+			path, err := json.CreatePath(p)
+			assertErr(t, err)
+			values, err := path.Values(v)
+			assertErr(t, err)
+			matches, err := path.ExtractWithLocation(src)
+			assertErr(t, err)
+			got := make([]string, 0, len(values))
+			for _, value := range values {
+				got = append(got, value.String())
+			}
+			expected := make([]string, 0, len(matches))
+			for _, m := range matches {
+				expected = append(expected, string(m.Value))
+			}
+			assertEq(t, p, strings.Join(expected, ","), strings.Join(got, ","))
+		}
+		path, err := json.CreatePath("$.items.id")
+		assertErr(t, err)
+		if _, err := path.Values(v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Get", func(t *testing.T) {
+		path, err := json.CreatePath("$.items[*].id")
+		assertErr(t, err)
+		var ids []int
+		assertErr(t, path.Get(v, &ids))
+		assertEq(t, "ids", "[1 3]", fmt.Sprint(ids))
+
+		var all json.Value
+		assertErr(t, path.Get(v, &all))
+		assertEq(t, "all", `[1,3]`, all.String())
+		item, err := json.CreatePath("$.items[0]")
+		assertErr(t, err)
+		var g json.Value
+		assertErr(t, item.Get(v, &g))
+		assertEq(t, "value", `{"id":1,"tags":["a","b"]}`, g.String())
+		var fromMap *json.Value
+		assertErr(t, item.Get(map[string]interface{}{"items": []interface{}{map[string]interface{}{"id": 1}}}, &fromMap))
+		assertEq(t, "from map", `{"id":1}`, fromMap.String())
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		src := []byte(`{"o": {"V": [12345678901234567890, {"k": "x"}]}, "a": [{"n": 1.50}, {"n": 2}]}`)
+		path, err := json.CreatePath("$.o.V")
+		assertErr(t, err)
+		var v json.Value
+		assertErr(t, path.Unmarshal(src, &v))
+		assertEq(t, "value", `[12345678901234567890,{"k":"x"}]`, v.String())
+		var pv *json.Value
+		assertErr(t, path.Unmarshal(src, &pv))
+		assertEq(t, "pointer", `[12345678901234567890,{"k":"x"}]`, pv.String())
+
+		all, err := json.CreatePath("$.a[*].n")
+		assertErr(t, err)
+		assertErr(t, all.Unmarshal(src, &v))
+		assertEq(t, "all", `[1.50,2]`, v.String())
+
+		obj, err := json.CreatePath("$.a[0]")
+		assertErr(t, err)
+		var st struct {
+			N json.Value `json:"n"`
+		}
+		assertErr(t, obj.Unmarshal([]byte(`{"a": [{"N": {"k": [1, "x"]}}]}`), &st))
+		assertEq(t, "field", `{"k":[1,"x"]}`, st.N.String())
+
+		missing, err := json.CreatePath("$.x")
+		assertErr(t, err)
+		if err := missing.Unmarshal(src, &v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestValueDuplicateKeys(t *testing.T) {
+	small := `{"a": 1, "b": 2, "a": 3}`
+	var keys []string
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprintf(`"k%d": %d`, i%12, i))
+	}
+	large := "{" + strings.Join(keys, ", ") + "}"
+	for _, tc := range []struct {
+		src      string
+		expected string
+	}{
+		{small, `{"a":3,"b":2}`},
+		{large, `{"k0":12,"k1":13,"k2":14,"k3":15,"k4":16,"k5":17,"k6":18,"k7":19,"k8":8,"k9":9,"k10":10,"k11":11}`},
+	} {
+		v, err := json.ParseValue([]byte(tc.src))
+		assertErr(t, err)
+		assertEq(t, "parse", tc.expected, v.String())
+		var sv json.Value
+		assertErr(t, json.NewDecoder(strings.NewReader(tc.src)).Decode(&sv))
+		assertEq(t, "stream", tc.expected, sv.String())
+	}
+}